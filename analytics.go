@@ -22,7 +22,29 @@ import (
 //	  "success": true,
 //	  "response": "<string>"
 //	}
+//
+// The response amount is parsed as Lamports.
+func (c *BagsClient) GetTokenLifetimeFeesLamports(ctx context.Context, tokenMint string) (Lamports, error) {
+	raw, err := c.getTokenLifetimeFees(ctx, tokenMint)
+	if err != nil {
+		return 0, err
+	}
+	fees, err := ParseLamports(raw)
+	if err != nil {
+		return 0, fmt.Errorf("parse lifetime fees: %w", err)
+	}
+	return fees, nil
+}
+
+// GetTokenLifetimeFees returns the lifetime fees for a token as the raw
+// string reported by the API.
+//
+// Deprecated: Use GetTokenLifetimeFeesLamports.
 func (c *BagsClient) GetTokenLifetimeFees(ctx context.Context, tokenMint string) (string, error) {
+	return c.getTokenLifetimeFees(ctx, tokenMint)
+}
+
+func (c *BagsClient) getTokenLifetimeFees(ctx context.Context, tokenMint string) (string, error) {
 	if tm := strings.TrimSpace(tokenMint); tm == "" {
 		return "", fmt.Errorf("tokenMint is required")
 	}
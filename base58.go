@@ -0,0 +1,83 @@
+// base58.go
+package bags
+
+import "fmt"
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Index = func() [256]int8 {
+	var idx [256]int8
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		idx[base58Alphabet[i]] = int8(i)
+	}
+	return idx
+}()
+
+// Base58Encode encodes b using the Bitcoin/Solana base58 alphabet, as used
+// for public keys, signatures, and blockhashes.
+func Base58Encode(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	// log(256)/log(58) ≈ 1.366
+	buf := make([]byte, (len(b)-zeros)*138/100+1)
+	high := len(buf) - 1
+	for _, v := range b[zeros:] {
+		carry := int(v)
+		j := len(buf) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += 256 * int(buf[j])
+			buf[j] = byte(carry % 58)
+			carry /= 58
+		}
+		high = j
+	}
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	out := make([]byte, zeros+len(buf)-i)
+	for k := 0; k < zeros; k++ {
+		out[k] = '1'
+	}
+	for k, v := range buf[i:] {
+		out[zeros+k] = base58Alphabet[v]
+	}
+	return string(out)
+}
+
+// Base58Decode decodes a base58 string.
+func Base58Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	// log(58)/log(256) ≈ 0.733
+	buf := make([]byte, (len(s)-zeros)*733/1000+1)
+	high := len(buf) - 1
+	for i := zeros; i < len(s); i++ {
+		d := base58Index[s[i]]
+		if d < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		carry := int(d)
+		j := len(buf) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += 58 * int(buf[j])
+			buf[j] = byte(carry % 256)
+			carry /= 256
+		}
+		high = j
+	}
+	i := 0
+	for i < len(buf) && buf[i] == 0 {
+		i++
+	}
+	out := make([]byte, zeros+len(buf)-i)
+	copy(out[zeros:], buf[i:])
+	return out, nil
+}
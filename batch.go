@@ -0,0 +1,324 @@
+// batch.go
+package bags
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the worker count used when BatchOptions.Concurrency is zero.
+const DefaultBatchConcurrency = 8
+
+// -------------------- Batch Analytics --------------------
+
+// BatchOptions configures batch helpers.
+type BatchOptions struct {
+	// Concurrency bounds the number of in-flight requests. Zero means
+	// DefaultBatchConcurrency.
+	Concurrency int
+}
+
+// BatchResult aggregates per-key results and errors from a batch call. Every
+// requested key appears in exactly one of Results or Errors.
+type BatchResult[T any] struct {
+	Results map[string]T
+	Errors  map[string]error
+
+	index map[string]int // key -> position in the requested keys
+}
+
+// Err returns a *BatchError describing every failed key, or nil when all
+// succeeded.
+func (r *BatchResult[T]) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	be := &BatchError{Total: len(r.Results) + len(r.Errors)}
+	for k, err := range r.Errors {
+		be.Items = append(be.Items, &BatchItemError{Index: r.index[k], Key: k, Err: err})
+	}
+	slices.SortFunc(be.Items, func(a, b *BatchItemError) int { return cmp.Compare(a.Index, b.Index) })
+	return be
+}
+
+// BatchItemError is the failure of one item of a batch call.
+type BatchItemError struct {
+	Index int    // position of the item in the requested keys
+	Key   string // e.g. the token mint
+	Err   error
+}
+
+func (e *BatchItemError) Error() string { return e.Key + ": " + e.Err.Error() }
+
+func (e *BatchItemError) Unwrap() error { return e.Err }
+
+// BatchError is returned by batch helpers when some items fail. Successful
+// items are still available in the accompanying result. errors.Is and
+// errors.As see through it to each item's error, e.g.
+// errors.Is(err, context.DeadlineExceeded) reports whether ctx ended before
+// every item ran.
+type BatchError struct {
+	Items []*BatchItemError // ordered by Index
+	Total int               // number of items in the batch
+}
+
+func (e *BatchError) Error() string {
+	const shown = 3
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d items failed: ", len(e.Items), e.Total)
+	for i, it := range e.Items[:min(shown, len(e.Items))] {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(it.Error())
+	}
+	if n := len(e.Items) - shown; n > 0 {
+		fmt.Fprintf(&b, "; and %d more", n)
+	}
+	return b.String()
+}
+
+// Unwrap returns the item errors.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, it := range e.Items {
+		errs[i] = it
+	}
+	return errs
+}
+
+// Keys returns the keys of the failed items in Index order.
+func (e *BatchError) Keys() []string {
+	keys := make([]string, len(e.Items))
+	for i, it := range e.Items {
+		keys[i] = it.Key
+	}
+	return keys
+}
+
+// GetTokenLifetimeFeesBatch fetches lifetime fees for many mints concurrently.
+//
+// Each request goes through the client like a direct GetTokenLifetimeFeesLamports
+// call. Duplicate and blank mints are ignored. The result is returned even
+// when some mints fail; the error is then a *BatchError listing them, and
+// the failures are also in BatchResult.Errors. Mints not requested before
+// ctx ended fail with ctx.Err().
+func (c *BagsClient) GetTokenLifetimeFeesBatch(ctx context.Context, mints []string, opts BatchOptions) (*BatchResult[Lamports], error) {
+	return runBatch(ctx, mints, opts, c.GetTokenLifetimeFeesLamports)
+}
+
+// GetTokenLaunchCreatorsBatch fetches launch creators for many mints
+// concurrently. It follows the same conventions as GetTokenLifetimeFeesBatch.
+func (c *BagsClient) GetTokenLaunchCreatorsBatch(ctx context.Context, mints []string, opts BatchOptions) (*BatchResult[[]TokenCreator], error) {
+	return runBatch(ctx, mints, opts, c.GetTokenLaunchCreators)
+}
+
+// -------------------- Multiplexed Queries --------------------
+
+// ErrQueryNotExecuted is returned by Query.Result before the batch holding
+// the query has executed it.
+var ErrQueryNotExecuted = errors.New("query not executed")
+
+// BatchQuery is a read query that can be added to a BatchRequest. Create one
+// with NewQuery or a typed constructor such as LifetimeFeesQuery.
+type BatchQuery interface {
+	exec(ctx context.Context, c *BagsClient)
+	fail(err error)
+}
+
+// Query is a typed BatchQuery whose result is available after Execute.
+type Query[T any] struct {
+	fn   func(ctx context.Context, c *BagsClient) (T, error)
+	val  T
+	err  error
+	done bool
+}
+
+// NewQuery wraps any read call as a batch query.
+func NewQuery[T any](fn func(ctx context.Context, c *BagsClient) (T, error)) *Query[T] {
+	return &Query[T]{fn: fn}
+}
+
+// Result returns the query's value and error.
+func (q *Query[T]) Result() (T, error) {
+	if !q.done {
+		var zero T
+		return zero, ErrQueryNotExecuted
+	}
+	return q.val, q.err
+}
+
+func (q *Query[T]) exec(ctx context.Context, c *BagsClient) {
+	q.val, q.err = q.fn(ctx, c)
+	q.done = true
+}
+
+func (q *Query[T]) fail(err error) {
+	if !q.done {
+		q.err, q.done = err, true
+	}
+}
+
+// LifetimeFeesQuery queries GetTokenLifetimeFeesLamports.
+func LifetimeFeesQuery(mint string) *Query[Lamports] {
+	return NewQuery(func(ctx context.Context, c *BagsClient) (Lamports, error) {
+		return c.GetTokenLifetimeFeesLamports(ctx, mint)
+	})
+}
+
+// CreatorsQuery queries GetTokenLaunchCreators.
+func CreatorsQuery(mint string) *Query[[]TokenCreator] {
+	return NewQuery(func(ctx context.Context, c *BagsClient) ([]TokenCreator, error) {
+		return c.GetTokenLaunchCreators(ctx, mint)
+	})
+}
+
+// FeeShareWalletQuery queries GetFeeShareWallet.
+func FeeShareWalletQuery(twitterUsername string) *Query[string] {
+	return NewQuery(func(ctx context.Context, c *BagsClient) (string, error) {
+		return c.GetFeeShareWallet(ctx, twitterUsername)
+	})
+}
+
+// ClaimablePositionsQuery queries GetClaimablePositions.
+func ClaimablePositionsQuery(wallet string) *Query[[]ClaimablePosition] {
+	return NewQuery(func(ctx context.Context, c *BagsClient) ([]ClaimablePosition, error) {
+		return c.GetClaimablePositions(ctx, wallet)
+	})
+}
+
+// BatchRequest collects read queries to run together.
+//
+// The Bags API has no batch endpoint, so Execute runs the queries as
+// individual requests with bounded concurrency. Callers written against this
+// interface will transparently benefit if a multiplexed endpoint appears.
+type BatchRequest struct {
+	c       *BagsClient
+	opts    BatchOptions
+	queries []BatchQuery
+}
+
+// Batch starts an empty batch request.
+//
+//	fees := bags.LifetimeFeesQuery(mint)
+//	creators := bags.CreatorsQuery(mint)
+//	err := client.Batch().Add(fees, creators).Execute(ctx)
+//	total, err := fees.Result()
+func (c *BagsClient) Batch() *BatchRequest {
+	return &BatchRequest{c: c}
+}
+
+// Concurrency bounds in-flight requests; zero means DefaultBatchConcurrency.
+func (b *BatchRequest) Concurrency(n int) *BatchRequest {
+	b.opts.Concurrency = n
+	return b
+}
+
+// Add appends queries to the batch. Queries already in the batch are
+// skipped.
+func (b *BatchRequest) Add(qs ...BatchQuery) *BatchRequest {
+	for _, q := range qs {
+		if q != nil && !slices.Contains(b.queries, q) {
+			b.queries = append(b.queries, q)
+		}
+	}
+	return b
+}
+
+// Execute runs every query. Per-query failures are reported by each query's
+// Result; the returned error is non-nil only when ctx ends first, in which
+// case queries that never ran fail with ctx.Err() and the error is a
+// *BatchError keyed by query position.
+func (b *BatchRequest) Execute(ctx context.Context) error {
+	keys := make([]string, len(b.queries))
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	res, err := runBatch(ctx, keys, b.opts, func(ctx context.Context, k string) (struct{}, error) {
+		i, _ := strconv.Atoi(k)
+		b.queries[i].exec(ctx, b.c)
+		return struct{}{}, nil
+	})
+	for k, qerr := range res.Errors {
+		i, _ := strconv.Atoi(k)
+		b.queries[i].fail(qerr)
+	}
+	return err
+}
+
+// ------- Internal Helpers -------
+
+// runBatch calls fn for each unique key with at most opts.Concurrency calls
+// in flight. It always returns the result, with out.Err() as the error.
+func runBatch[T any](ctx context.Context, keys []string, opts BatchOptions, fn func(context.Context, string) (T, error)) (*BatchResult[T], error) {
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = DefaultBatchConcurrency
+	}
+
+	uniq := make([]string, 0, len(keys))
+	index := make(map[string]int, len(keys))
+	for i, k := range keys {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		if _, ok := index[k]; ok {
+			continue
+		}
+		index[k] = i
+		uniq = append(uniq, k)
+	}
+	if workers > len(uniq) {
+		workers = len(uniq)
+	}
+
+	out := &BatchResult[T]{
+		Results: make(map[string]T, len(uniq)),
+		Errors:  make(map[string]error),
+		index:   index,
+	}
+	var mu sync.Mutex
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range jobs {
+				v, err := fn(ctx, k)
+				mu.Lock()
+				if err != nil {
+					out.Errors[k] = err
+				} else {
+					out.Results[k] = v
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	sent := 0
+feed:
+	for _, k := range uniq {
+		select {
+		case jobs <- k:
+			sent++
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, k := range uniq[sent:] {
+		out.Errors[k] = ctx.Err()
+	}
+	return out, out.Err()
+}
@@ -0,0 +1,195 @@
+// blocklist.go
+package bags
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTokenBlocked is returned by CreateFeeShareConfig for a base mint on the
+// client's blocklist.
+var ErrTokenBlocked = errors.New("token is blocklisted")
+
+// -------------------- Token Blocklist --------------------
+
+// BlocklistSource supplies the set of token mints flagged as scams.
+type BlocklistSource interface {
+	BlockedTokens(ctx context.Context) ([]string, error)
+}
+
+// BlocklistFunc adapts a function to a BlocklistSource.
+type BlocklistFunc func(ctx context.Context) ([]string, error)
+
+// BlockedTokens calls f(ctx).
+func (f BlocklistFunc) BlockedTokens(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// StaticBlocklist is a fixed list of blocked mints.
+type StaticBlocklist []string
+
+// BlockedTokens returns a copy of the list.
+func (s StaticBlocklist) BlockedTokens(context.Context) ([]string, error) {
+	return append([]string(nil), s...), nil
+}
+
+// HTTPBlocklist downloads a blocklist from URL. The body may be a JSON array
+// of mints or plain text with one mint per line ("#" starts a comment).
+type HTTPBlocklist struct {
+	URL  string
+	HTTP *http.Client // defaults to http.DefaultClient
+}
+
+// BlockedTokens fetches and parses the list.
+func (h *HTTPBlocklist) BlockedTokens(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	hc := h.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch blocklist: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("fetch blocklist: %s", res.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(res.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read blocklist: %w", err)
+	}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		var mints []string
+		if err := json.Unmarshal(data, &mints); err != nil {
+			return nil, fmt.Errorf("decode blocklist: %w", err)
+		}
+		return mints, nil
+	}
+	var mints []string
+	sc := bufio.NewScanner(strings.NewReader(string(data)))
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			mints = append(mints, line)
+		}
+	}
+	return mints, sc.Err()
+}
+
+// CachedBlocklist wraps a source and refreshes it at most once per TTL. When
+// a refresh fails the previously synced list keeps being served.
+type CachedBlocklist struct {
+	Source BlocklistSource
+	TTL    time.Duration
+
+	mu      sync.Mutex
+	mints   map[string]struct{}
+	list    []string
+	fetched time.Time
+}
+
+// NewCachedBlocklist returns a CachedBlocklist over src.
+func NewCachedBlocklist(src BlocklistSource, ttl time.Duration) *CachedBlocklist {
+	return &CachedBlocklist{Source: src, TTL: ttl}
+}
+
+// BlockedTokens returns the cached list, syncing from Source when stale.
+func (b *CachedBlocklist) BlockedTokens(ctx context.Context) ([]string, error) {
+	if err := b.sync(ctx); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.list...), nil
+}
+
+func (b *CachedBlocklist) contains(ctx context.Context, mint string) (bool, error) {
+	if err := b.sync(ctx); err != nil {
+		return false, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, ok := b.mints[mint]
+	return ok, nil
+}
+
+func (b *CachedBlocklist) sync(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.mints != nil && time.Since(b.fetched) < b.TTL {
+		return nil
+	}
+	list, err := b.Source.BlockedTokens(ctx)
+	if err != nil {
+		if b.mints != nil {
+			return nil
+		}
+		return err
+	}
+	b.list = list
+	b.mints = make(map[string]struct{}, len(list))
+	for _, m := range list {
+		b.mints[strings.TrimSpace(m)] = struct{}{}
+	}
+	b.fetched = time.Now()
+	return nil
+}
+
+// GetBlockedTokens returns the mints flagged by the client's blocklist, or
+// nil when no blocklist is configured.
+func (c *BagsClient) GetBlockedTokens(ctx context.Context) ([]string, error) {
+	if c.Blocklist == nil {
+		return nil, nil
+	}
+	return c.Blocklist.BlockedTokens(ctx)
+}
+
+// IsTokenBlocked reports whether mint is on the client's blocklist. It always
+// reports false when no blocklist is configured.
+func (c *BagsClient) IsTokenBlocked(ctx context.Context, mint string) (bool, error) {
+	mint = strings.TrimSpace(mint)
+	if c.Blocklist == nil || mint == "" {
+		return false, nil
+	}
+	if cb, ok := c.Blocklist.(*CachedBlocklist); ok {
+		return cb.contains(ctx, mint)
+	}
+	list, err := c.Blocklist.BlockedTokens(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range list {
+		if strings.TrimSpace(m) == mint {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkTokenAllowed returns ErrTokenBlocked (wrapped with the mint) when mint
+// is blocklisted.
+func (c *BagsClient) checkTokenAllowed(ctx context.Context, mint string) error {
+	blocked, err := c.IsTokenBlocked(ctx, mint)
+	if err != nil {
+		return fmt.Errorf("check blocklist: %w", err)
+	}
+	if blocked {
+		return fmt.Errorf("%w: %s", ErrTokenBlocked, mint)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// breaker.go
+package bags
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped with the endpoint path, when a request
+// is rejected because that endpoint's circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// -------------------- Circuit Breakers --------------------
+
+// BreakerPolicy configures the per-endpoint circuit breakers. Each endpoint
+// path has its own breaker, so an outage on one endpoint does not block
+// calls to others sharing the client.
+type BreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failed attempts (network
+	// errors, 429, 500, 502, 503, 504) that opens an endpoint's breaker.
+	// Zero disables circuit breaking.
+	FailureThreshold int
+	// OpenTimeout is how long a breaker stays open before letting a single
+	// probe request through. Zero means 30s.
+	OpenTimeout time.Duration
+}
+
+// BreakerState is the state of one endpoint's breaker.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // requests flow normally
+	BreakerOpen                         // requests fail fast with ErrCircuitOpen
+	BreakerHalfOpen                     // one probe request is allowed through
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// MarshalText encodes the state as its name.
+func (s BreakerState) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// BreakerStatus is a snapshot of one endpoint's breaker.
+type BreakerStatus struct {
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	OpenedAt            time.Time    `json:"openedAt,omitzero"` // zero while closed
+}
+
+// BreakerStates returns the breaker status of every endpoint the client has
+// called, keyed by endpoint path (e.g. "token-launch/creator/v2").
+func (c *BagsClient) BreakerStates() map[string]BreakerStatus {
+	policy := c.Config().Breaker
+	now := time.Now()
+	out := make(map[string]BreakerStatus)
+	c.breakers.Range(func(k, v any) bool {
+		out[k.(string)] = v.(*breaker).status(policy, now)
+		return true
+	})
+	return out
+}
+
+// ResetBreakers closes every breaker.
+func (c *BagsClient) ResetBreakers() {
+	c.breakers.Clear()
+}
+
+// ------- Internal Helpers -------
+
+type breaker struct {
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func (c *BagsClient) breakerFor(req *http.Request, p BreakerPolicy) *breaker {
+	if p.FailureThreshold <= 0 {
+		return nil
+	}
+	key := c.endpointPath(req.URL)
+	v, ok := c.breakers.Load(key)
+	if !ok {
+		v, _ = c.breakers.LoadOrStore(key, new(breaker))
+	}
+	return v.(*breaker)
+}
+
+func (p BreakerPolicy) openTimeout() time.Duration {
+	if p.OpenTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return p.OpenTimeout
+}
+
+// allow reports whether an attempt may proceed, moving an expired open
+// breaker to half-open and admitting one probe.
+func (b *breaker) allow(p BreakerPolicy) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < p.openTimeout() {
+			return false
+		}
+		b.state = BreakerHalfOpen
+		fallthrough
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of an allowed attempt.
+func (b *breaker) record(p BreakerPolicy, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !failed {
+		b.state, b.failures, b.openedAt = BreakerClosed, 0, time.Time{}
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= p.FailureThreshold {
+		b.state, b.openedAt = BreakerOpen, time.Now()
+	}
+}
+
+// release undoes allow for an attempt that never reached the network.
+func (b *breaker) release() {
+	b.mu.Lock()
+	b.probing = false
+	b.mu.Unlock()
+}
+
+func (b *breaker) status(p BreakerPolicy, now time.Time) BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := BreakerStatus{State: b.state, ConsecutiveFailures: b.failures, OpenedAt: b.openedAt}
+	if st.State == BreakerOpen && now.Sub(b.openedAt) >= p.openTimeout() {
+		st.State = BreakerHalfOpen
+	}
+	return st
+}
+
+// breakerFailure reports whether an attempt outcome counts against the
+// endpoint's breaker. Caller cancellations do not.
+func breakerFailure(req *http.Request, res *http.Response, err error) bool {
+	if err != nil {
+		return req.Context().Err() == nil
+	}
+	return retryable(req.Context(), res, nil)
+}
@@ -0,0 +1,62 @@
+// budget.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrCallBudgetExceeded is returned when a request would exceed the call
+// budget attached to its context with WithCallBudget.
+var ErrCallBudgetExceeded = errors.New("api call budget exceeded")
+
+type callBudgetKey struct{}
+
+type callBudget struct {
+	limit     int64
+	remaining atomic.Int64
+	parent    *callBudget
+}
+
+// WithCallBudget returns a context that allows at most n API calls through
+// any client using it. Every HTTP attempt counts, including retries and calls
+// made internally by helpers and batch operations.
+//
+// Budgets nest: a call is only allowed when every enclosing budget has room,
+// so an inner budget can never raise an outer limit.
+func WithCallBudget(ctx context.Context, n int) context.Context {
+	b := &callBudget{limit: int64(n), parent: budgetFrom(ctx)}
+	b.remaining.Store(int64(n))
+	return context.WithValue(ctx, callBudgetKey{}, b)
+}
+
+// CallBudgetRemaining reports the calls left in the innermost budget on ctx.
+// The second result is false when ctx carries no budget.
+func CallBudgetRemaining(ctx context.Context) (int, bool) {
+	b := budgetFrom(ctx)
+	if b == nil {
+		return 0, false
+	}
+	return int(max(b.remaining.Load(), 0)), true
+}
+
+func budgetFrom(ctx context.Context) *callBudget {
+	b, _ := ctx.Value(callBudgetKey{}).(*callBudget)
+	return b
+}
+
+// takeCallBudget consumes one call from every budget on ctx, or none if any
+// budget is exhausted.
+func takeCallBudget(ctx context.Context) error {
+	for b := budgetFrom(ctx); b != nil; b = b.parent {
+		if b.remaining.Add(-1) < 0 {
+			for r := budgetFrom(ctx); r != b.parent; r = r.parent {
+				r.remaining.Add(1)
+			}
+			return fmt.Errorf("%w (limit %d)", ErrCallBudgetExceeded, b.limit)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// cache.go
+package bags
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long a cached response is fresh when
+// ResponseCache.TTL is zero.
+const DefaultCacheTTL = 30 * time.Second
+
+// DefaultCacheEndpoints are the endpoints cached when
+// ResponseCache.Endpoints is empty: the analytics reads.
+var DefaultCacheEndpoints = []string{"token-launch/lifetime-fees", "token-launch/creator/v2"}
+
+// -------------------- Response Cache --------------------
+
+// ResponseCache caches successful GET responses in a StateStore. Use a
+// MemoryStateStore within one process, or a FileStateStore to share cached
+// data across CLI invocations and short-lived jobs.
+//
+// Entries are scoped to the API key (by hash) and the tenant set with
+// WithTenant, so clients and tenants sharing a store never see each
+// other's responses. A response younger than TTL is served from the cache. Up to StaleTTL
+// after that it is still served, but refreshed in the background so the
+// next call sees fresh data (stale-while-revalidate). Older entries are
+// refetched before returning.
+type ResponseCache struct {
+	Store StateStore
+	// TTL is how long a response is fresh. Zero means DefaultCacheTTL.
+	TTL time.Duration
+	// StaleTTL is how long past TTL a response may be served while it is
+	// revalidated. Zero disables stale serving, which suits processes that
+	// exit before a background refresh could finish.
+	StaleTTL time.Duration
+	// Endpoints lists the endpoint paths to cache. Empty means
+	// DefaultCacheEndpoints.
+	Endpoints []string
+
+	mu        sync.Mutex
+	refreshes map[string]bool // keys being revalidated
+}
+
+// NewResponseCache returns a cache over store.
+func NewResponseCache(store StateStore, ttl, staleTTL time.Duration) *ResponseCache {
+	return &ResponseCache{Store: store, TTL: ttl, StaleTTL: staleTTL}
+}
+
+// PurgeCache removes the cached response for the request URL u under the
+// current API key and ctx's tenant, e.g. after a mutation the caller knows
+// invalidates it. It does nothing when c.Cache is nil.
+func (c *BagsClient) PurgeCache(ctx context.Context, u string) error {
+	if c.Cache == nil || c.Cache.Store == nil {
+		return nil
+	}
+	return c.Cache.Store.Delete(ctx, cacheKey(u, c.Config().APIKey, TenantFrom(ctx)))
+}
+
+// ------- Internal Helpers -------
+
+type cacheEntry struct {
+	StoredAt time.Time `json:"storedAt"`
+	Body     []byte    `json:"body"`
+}
+
+// cacheKey hashes the request URL together with the API key and tenant it
+// was made for.
+func cacheKey(u, apiKey, tenant string) string {
+	h := sha256.New()
+	for _, part := range []string{u, apiKey, tenant} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return "http-cache/" + hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// handles reports whether req is served through the cache.
+func (rc *ResponseCache) handles(c *BagsClient, req *http.Request) bool {
+	if rc == nil || rc.Store == nil || req.Method != http.MethodGet {
+		return false
+	}
+	eps := rc.Endpoints
+	if len(eps) == 0 {
+		eps = DefaultCacheEndpoints
+	}
+	return slices.Contains(eps, c.endpointPath(req.URL))
+}
+
+// body returns the response body for req from the cache or the network.
+func (rc *ResponseCache) body(c *BagsClient, req *http.Request) ([]byte, error) {
+	ctx := req.Context()
+	key := cacheKey(req.URL.String(), req.Header.Get("x-api-key"), TenantFrom(ctx))
+	ttl := rc.TTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	var e cacheEntry
+	if raw, err := rc.Store.Load(ctx, key); err == nil && json.Unmarshal(raw, &e) == nil {
+		switch age := time.Since(e.StoredAt); {
+		case age < ttl:
+			return e.Body, nil
+		case age < ttl+rc.StaleTTL:
+			rc.revalidate(c, req, key)
+			return e.Body, nil
+		}
+	}
+	return rc.fetch(c, req, key)
+}
+
+// fetch performs req and stores a successful body under key. Store errors
+// are ignored; the cache is an optimization.
+func (rc *ResponseCache) fetch(c *BagsClient, req *http.Request, key string) ([]byte, error) {
+	data, err := c.fetchBody(req, true)
+	if err != nil {
+		return nil, err
+	}
+	var env struct {
+		Success *bool `json:"success"`
+	}
+	if json.Unmarshal(data, &env) == nil && (env.Success == nil || *env.Success) {
+		if raw, err := json.Marshal(cacheEntry{StoredAt: time.Now(), Body: data}); err == nil {
+			_ = rc.Store.Save(req.Context(), key, raw)
+		}
+	}
+	return data, nil
+}
+
+// revalidate refreshes key in the background unless a refresh is already
+// running. The refresh outlives req's context but keeps its values.
+func (rc *ResponseCache) revalidate(c *BagsClient, req *http.Request, key string) {
+	rc.mu.Lock()
+	if rc.refreshes[key] {
+		rc.mu.Unlock()
+		return
+	}
+	if rc.refreshes == nil {
+		rc.refreshes = make(map[string]bool)
+	}
+	rc.refreshes[key] = true
+	rc.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), time.Minute)
+	next := req.Clone(ctx)
+	go func() {
+		defer cancel()
+		_, _ = rc.fetch(c, next, key)
+		rc.mu.Lock()
+		delete(rc.refreshes, key)
+		rc.mu.Unlock()
+	}()
+}
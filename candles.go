@@ -0,0 +1,178 @@
+// candles.go
+package bags
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CandlesPageSize is the number of candles requested per source call when
+// iterating over long ranges.
+const CandlesPageSize = 500
+
+// Common candle intervals.
+const (
+	Candle1m  = time.Minute
+	Candle5m  = 5 * time.Minute
+	Candle15m = 15 * time.Minute
+	Candle1h  = time.Hour
+	Candle4h  = 4 * time.Hour
+	Candle1d  = 24 * time.Hour
+)
+
+// -------------------- OHLCV Candles --------------------
+
+// Candle is one OHLCV bar. Prices are in SOL per whole token.
+type Candle struct {
+	Time        time.Time `json:"time"` // start of the interval, UTC
+	Open        Decimal   `json:"open"`
+	High        Decimal   `json:"high"`
+	Low         Decimal   `json:"low"`
+	Close       Decimal   `json:"close"`
+	Volume      Lamports  `json:"volume"`      // SOL traded
+	TokenVolume uint64    `json:"tokenVolume"` // tokens traded, in base units
+	Trades      int       `json:"trades"`
+}
+
+// TimeRange is the half-open interval [Start, End).
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// CandleSource returns candles of mint for r, oldest first, at most limit.
+type CandleSource interface {
+	Candles(ctx context.Context, mint string, interval time.Duration, r TimeRange, limit int) ([]Candle, error)
+}
+
+// CandleSourceFunc adapts a function to a CandleSource.
+type CandleSourceFunc func(ctx context.Context, mint string, interval time.Duration, r TimeRange, limit int) ([]Candle, error)
+
+func (f CandleSourceFunc) Candles(ctx context.Context, mint string, interval time.Duration, r TimeRange, limit int) ([]Candle, error) {
+	return f(ctx, mint, interval, r, limit)
+}
+
+// GetCandles returns all candles of mint in r. Long ranges are fetched in
+// pages of CandlesPageSize; use Candles to process them incrementally.
+func (c *BagsClient) GetCandles(ctx context.Context, mint string, interval time.Duration, r TimeRange) ([]Candle, error) {
+	var out []Candle
+	for cd, err := range c.Candles(ctx, mint, interval, r) {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, cd)
+	}
+	return out, nil
+}
+
+// Candles iterates over the candles of mint in r, oldest first, fetching a
+// page of CandlesPageSize intervals at a time. Iteration stops after the
+// first error.
+func (c *BagsClient) Candles(ctx context.Context, mint string, interval time.Duration, r TimeRange) iter.Seq2[Candle, error] {
+	return func(yield func(Candle, error) bool) {
+		switch {
+		case strings.TrimSpace(mint) == "":
+			yield(Candle{}, fmt.Errorf("mint is required"))
+			return
+		case interval <= 0:
+			yield(Candle{}, fmt.Errorf("interval must be positive"))
+			return
+		case !r.End.After(r.Start):
+			yield(Candle{}, fmt.Errorf("time range end must be after start"))
+			return
+		case c.CandleSource == nil:
+			yield(Candle{}, fmt.Errorf("no candle source configured"))
+			return
+		}
+		page := interval * CandlesPageSize
+		for start := alignUnix(r.Start, interval); start.Before(r.End); start = start.Add(page) {
+			end := start.Add(page)
+			if end.After(r.End) {
+				end = r.End
+			}
+			cs, err := c.CandleSource.Candles(ctx, mint, interval, TimeRange{start, end}, CandlesPageSize)
+			if err != nil {
+				yield(Candle{}, fmt.Errorf("candles %s..%s: %w", start.Format(time.RFC3339), end.Format(time.RFC3339), err))
+				return
+			}
+			for _, cd := range cs {
+				// Pages start on an interval boundary, which may precede
+				// r.Start; only candles starting within r are yielded.
+				if cd.Time.Before(start) || cd.Time.Before(r.Start) || !cd.Time.Before(end) {
+					continue
+				}
+				if !yield(cd, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// -------------------- Aggregation --------------------
+
+// ResampleCandles merges candles into bars of a longer interval, e.g. 1m into
+// 15m. Bars are aligned to multiples of interval since the Unix epoch (UTC).
+// Input order does not matter; the result is oldest first.
+func ResampleCandles(cs []Candle, interval time.Duration) ([]Candle, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	sorted := slices.Clone(cs)
+	slices.SortStableFunc(sorted, func(a, b Candle) int { return a.Time.Compare(b.Time) })
+	var out []Candle
+	for _, cd := range sorted {
+		t := alignUnix(cd.Time, interval)
+		if n := len(out); n > 0 && out[n-1].Time.Equal(t) {
+			last := &out[n-1]
+			if cd.High.Cmp(last.High) > 0 {
+				last.High = cd.High
+			}
+			if cd.Low.Cmp(last.Low) < 0 {
+				last.Low = cd.Low
+			}
+			last.Close = cd.Close
+			last.Volume += cd.Volume
+			last.TokenVolume += cd.TokenVolume
+			last.Trades += cd.Trades
+			continue
+		}
+		cd.Time = t
+		out = append(out, cd)
+	}
+	return out, nil
+}
+
+// AggregateTrades builds candles from trades, e.g. those received from
+// Streams().Trades. Intervals without trades are omitted.
+func AggregateTrades(trades []Trade, interval time.Duration) ([]Candle, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	cs := make([]Candle, 0, len(trades))
+	for _, t := range trades {
+		cs = append(cs, Candle{
+			Time: t.Time, Open: t.PriceSOL, High: t.PriceSOL, Low: t.PriceSOL, Close: t.PriceSOL,
+			Volume: t.SOLAmount, TokenVolume: t.TokenAmount, Trades: 1,
+		})
+	}
+	return ResampleCandles(cs, interval)
+}
+
+// ------- Internal Helpers -------
+
+// alignUnix rounds t down to a multiple of d since the Unix epoch, in UTC.
+// time.Truncate would align to the zero time instead, which differs for
+// intervals that do not divide a day.
+func alignUnix(t time.Time, d time.Duration) time.Time {
+	ns := t.UnixNano()
+	m := ns % int64(d)
+	if m < 0 {
+		m += int64(d)
+	}
+	return time.Unix(0, ns-m).UTC()
+}
@@ -0,0 +1,289 @@
+// claim.go
+package bags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// -------------------- Types (from docs) --------------------
+
+// ClaimablePosition is one token position with fees a wallet can claim.
+// GET https://public-api-v2.bags.fm/api/v1/token-launch/claimable-positions?wallet=<wallet>
+// Ref: https://bags.mintlify.app/api-reference/get-claimable-positions
+type ClaimablePosition struct {
+	IsCustomFeeVault           bool              `json:"isCustomFeeVault"`
+	BaseMint                   string            `json:"baseMint"`
+	QuoteMint                  string            `json:"quoteMint"`
+	VirtualPoolAddress         string            `json:"virtualPoolAddress"`
+	VirtualPoolClaimableAmount Lamports          `json:"virtualPoolClaimableAmount"`
+	IsMigrated                 bool              `json:"isMigrated"`
+	DammPoolAddress            string            `json:"dammPoolAddress,omitempty"`
+	DammPoolClaimableAmount    Lamports          `json:"dammPoolClaimableAmount"`
+	DammPositionInfo           *DammPositionInfo `json:"dammPositionInfo,omitempty"`
+	User                       string            `json:"user,omitempty"`
+	ClaimerIndex               int               `json:"claimerIndex,omitempty"`
+	UserBps                    int64             `json:"userBps,omitempty"`
+	CustomFeeVault             string            `json:"customFeeVault,omitempty"`
+	CustomFeeVaultClaimerA     string            `json:"customFeeVaultClaimerA,omitempty"`
+	CustomFeeVaultClaimerB     string            `json:"customFeeVaultClaimerB,omitempty"`
+	CustomFeeVaultClaimerSide  string            `json:"customFeeVaultClaimerSide,omitempty"` // "A" or "B"
+
+	TotalClaimableLamportsUserShare Lamports `json:"totalClaimableLamportsUserShare"`
+}
+
+// DammPositionInfo identifies the DAMM v2 position of a migrated token.
+type DammPositionInfo struct {
+	Position           string `json:"position"`
+	Pool               string `json:"pool"`
+	PositionNftAccount string `json:"positionNftAccount"`
+	TokenAMint         string `json:"tokenAMint"`
+	TokenBMint         string `json:"tokenBMint"`
+	TokenAVault        string `json:"tokenAVault"`
+	TokenBVault        string `json:"tokenBVault"`
+}
+
+// Claimable returns the amount the querying wallet can claim from the
+// position: its user share when reported, otherwise the pool totals.
+func (p *ClaimablePosition) Claimable() Lamports {
+	if p.TotalClaimableLamportsUserShare > 0 {
+		return p.TotalClaimableLamportsUserShare
+	}
+	return p.VirtualPoolClaimableAmount + p.DammPoolClaimableAmount
+}
+
+// ClaimTransactionsRequest asks for the transactions that claim one
+// position's fees. Build it from a position with NewClaimTransactionsRequest.
+// POST https://public-api-v2.bags.fm/api/v1/token-launch/claim-txs (application/json)
+// Ref: https://bags.mintlify.app/api-reference/get-claim-transactions
+type ClaimTransactionsRequest struct {
+	FeeClaimer                string `json:"feeClaimer"`
+	TokenMint                 string `json:"tokenMint"`
+	VirtualPoolAddress        string `json:"virtualPoolAddress,omitempty"`
+	DammV2Position            string `json:"dammV2Position,omitempty"`
+	DammV2Pool                string `json:"dammV2Pool,omitempty"`
+	DammV2PositionNftAccount  string `json:"dammV2PositionNftAccount,omitempty"`
+	TokenAMint                string `json:"tokenAMint,omitempty"`
+	TokenBMint                string `json:"tokenBMint,omitempty"`
+	TokenAVault               string `json:"tokenAVault,omitempty"`
+	TokenBVault               string `json:"tokenBVault,omitempty"`
+	ClaimVirtualPoolFees      bool   `json:"claimVirtualPoolFees"`
+	ClaimDammV2Fees           bool   `json:"claimDammV2Fees"`
+	IsCustomFeeVault          bool   `json:"isCustomFeeVault"`
+	CustomFeeVaultClaimerA    string `json:"customFeeVaultClaimerA,omitempty"`
+	CustomFeeVaultClaimerB    string `json:"customFeeVaultClaimerB,omitempty"`
+	CustomFeeVaultClaimerSide string `json:"customFeeVaultClaimerSide,omitempty"`
+}
+
+// ClaimTransaction is an unsigned claim transaction for the fee claimer to
+// sign and send. Tx is base58-encoded.
+type ClaimTransaction struct {
+	Tx        string `json:"tx"`
+	Blockhash struct {
+		Blockhash            string `json:"blockhash"`
+		LastValidBlockHeight uint64 `json:"lastValidBlockHeight"`
+	} `json:"blockhash"`
+}
+
+// Transaction decodes Tx, accepting base64 as well in case the encoding
+// changes.
+func (ct *ClaimTransaction) Transaction() (*Transaction, error) {
+	if raw, err := Base58Decode(strings.TrimSpace(ct.Tx)); err == nil {
+		if tx, err := ParseTransaction(raw); err == nil {
+			return tx, nil
+		}
+	}
+	return DecodeTransaction(ct.Tx)
+}
+
+// Signature decodes Tx and returns its transaction ID, which is zero until
+// the fee claimer has signed.
+func (ct *ClaimTransaction) Signature() (Signature, error) {
+	tx, err := ct.Transaction()
+	if err != nil {
+		return Signature{}, err
+	}
+	return tx.Signature(), nil
+}
+
+// NewClaimTransactionsRequest builds the claim request for a position
+// returned by GetClaimablePositions, claiming from whichever pools have a
+// balance.
+func NewClaimTransactionsRequest(feeClaimer string, p *ClaimablePosition) *ClaimTransactionsRequest {
+	in := &ClaimTransactionsRequest{
+		FeeClaimer:                feeClaimer,
+		TokenMint:                 p.BaseMint,
+		VirtualPoolAddress:        p.VirtualPoolAddress,
+		ClaimVirtualPoolFees:      p.VirtualPoolClaimableAmount > 0,
+		ClaimDammV2Fees:           p.IsMigrated && p.DammPoolClaimableAmount > 0 && p.DammPositionInfo != nil,
+		IsCustomFeeVault:          p.IsCustomFeeVault,
+		CustomFeeVaultClaimerA:    p.CustomFeeVaultClaimerA,
+		CustomFeeVaultClaimerB:    p.CustomFeeVaultClaimerB,
+		CustomFeeVaultClaimerSide: p.CustomFeeVaultClaimerSide,
+	}
+	if d := p.DammPositionInfo; in.ClaimDammV2Fees {
+		in.DammV2Position, in.DammV2Pool, in.DammV2PositionNftAccount = d.Position, d.Pool, d.PositionNftAccount
+		in.TokenAMint, in.TokenBMint = d.TokenAMint, d.TokenBMint
+		in.TokenAVault, in.TokenBVault = d.TokenAVault, d.TokenBVault
+	}
+	// Positions that only report the user share still need a claim.
+	if !in.ClaimVirtualPoolFees && !in.ClaimDammV2Fees && p.Claimable() > 0 {
+		in.ClaimVirtualPoolFees = true
+	}
+	return in
+}
+
+// -------------------- Methods --------------------
+
+// GetClaimablePositions lists the positions with fees claimable by wallet.
+// Endpoint: GET token-launch/claimable-positions?wallet=<wallet>
+func (c *BagsClient) GetClaimablePositions(ctx context.Context, wallet string) ([]ClaimablePosition, error) {
+	if strings.TrimSpace(wallet) == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	req, err := c.newRequest(ctx, http.MethodGet,
+		"token-launch/claimable-positions?wallet="+url.QueryEscape(wallet), nil, "")
+	if err != nil {
+		return nil, err
+	}
+	var env struct {
+		Success  bool                `json:"success"`
+		Response []ClaimablePosition `json:"response"`
+	}
+	if err := c.do(req, &env); err != nil {
+		return nil, err
+	}
+	if !env.Success {
+		return nil, fmt.Errorf("unexpected response")
+	}
+	return env.Response, nil
+}
+
+// CreateClaimTransactions returns the transactions that claim a position.
+// Endpoint: POST token-launch/claim-txs (application/json)
+func (c *BagsClient) CreateClaimTransactions(ctx context.Context, in *ClaimTransactionsRequest) ([]ClaimTransaction, error) {
+	if in == nil || strings.TrimSpace(in.FeeClaimer) == "" || strings.TrimSpace(in.TokenMint) == "" {
+		return nil, fmt.Errorf("feeClaimer and tokenMint are required")
+	}
+	if err := c.validators.run(in); err != nil {
+		return nil, err
+	}
+	var env struct {
+		Success  bool               `json:"success"`
+		Response []ClaimTransaction `json:"response"`
+	}
+	if err := c.postJSON(ctx, "token-launch/claim-txs", in, &env); err != nil {
+		return nil, err
+	}
+	if !env.Success || len(env.Response) == 0 {
+		return nil, fmt.Errorf("unexpected response")
+	}
+	return env.Response, nil
+}
+
+// -------------------- Claim By Twitter --------------------
+
+// TwitterClaimables is what a Twitter user can claim across their tokens.
+type TwitterClaimables struct {
+	TwitterUsername string
+	Wallet          string              // fee share wallet of the handle
+	Positions       []ClaimablePosition // only positions with a claimable balance
+	Total           Lamports
+}
+
+// PositionsFor returns every claimable position on mint. A wallet can hold
+// several, e.g. a virtual pool position and a custom fee vault.
+func (t *TwitterClaimables) PositionsFor(mint string) []ClaimablePosition {
+	var out []ClaimablePosition
+	for _, p := range t.Positions {
+		if p.BaseMint == mint {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// GetClaimablesByTwitter resolves a Twitter handle to its fee share wallet
+// and lists the tokens it has claimable fees on, largest first.
+func (c *BagsClient) GetClaimablesByTwitter(ctx context.Context, twitterUsername string) (*TwitterClaimables, error) {
+	handle := strings.TrimPrefix(strings.TrimSpace(twitterUsername), "@")
+	wallet, err := c.feeShareWallet(ctx, handle)
+	if err != nil {
+		return nil, fmt.Errorf("resolve @%s: %w", handle, err)
+	}
+	positions, err := c.GetClaimablePositions(ctx, wallet)
+	if err != nil {
+		return nil, fmt.Errorf("claimable positions for @%s: %w", handle, err)
+	}
+	out := &TwitterClaimables{TwitterUsername: handle, Wallet: wallet}
+	for _, p := range positions {
+		if amt := p.Claimable(); amt > 0 {
+			out.Positions = append(out.Positions, p)
+			out.Total += amt
+		}
+	}
+	slices.SortStableFunc(out.Positions, func(a, b ClaimablePosition) int {
+		x, y := a.Claimable(), b.Claimable()
+		switch {
+		case x > y:
+			return -1
+		case x < y:
+			return 1
+		}
+		return 0
+	})
+	return out, nil
+}
+
+// TwitterClaimTransactions are the claim transactions built for a Twitter
+// user. Every requested mint appears in exactly one of Transactions or Errors.
+type TwitterClaimTransactions struct {
+	Claimables   *TwitterClaimables
+	Transactions map[string][]ClaimTransaction // by token mint
+	Errors       map[string]error
+}
+
+// CreateClaimTransactionsByTwitter builds claim transactions for the given
+// mints of a Twitter user, or for every claimable mint when mints is empty.
+// Each mint's transactions cover all of its claimable positions. The
+// transactions are paid and signed by the user's fee share wallet.
+// Mints without a claimable balance are reported in Errors. When any mint
+// fails, the result is returned together with a *BatchError listing the
+// failures.
+func (c *BagsClient) CreateClaimTransactionsByTwitter(ctx context.Context, twitterUsername string, mints []string, opts BatchOptions) (*TwitterClaimTransactions, error) {
+	claims, err := c.GetClaimablesByTwitter(ctx, twitterUsername)
+	if err != nil {
+		return nil, err
+	}
+	if len(mints) == 0 {
+		for _, p := range claims.Positions {
+			mints = append(mints, p.BaseMint)
+		}
+	}
+	seen := make(map[string]bool, len(mints))
+	mints = slices.DeleteFunc(slices.Clone(mints), func(m string) bool {
+		dup := seen[m]
+		seen[m] = true
+		return dup
+	})
+	res, err := runBatch(ctx, mints, opts, func(ctx context.Context, mint string) ([]ClaimTransaction, error) {
+		positions := claims.PositionsFor(mint)
+		if len(positions) == 0 {
+			return nil, fmt.Errorf("@%s has no claimable fees on %s", claims.TwitterUsername, mint)
+		}
+		var txs []ClaimTransaction
+		for i := range positions {
+			t, err := c.CreateClaimTransactions(ctx, NewClaimTransactionsRequest(claims.Wallet, &positions[i]))
+			if err != nil {
+				return nil, err
+			}
+			txs = append(txs, t...)
+		}
+		return txs, nil
+	})
+	return &TwitterClaimTransactions{Claimables: claims, Transactions: res.Results, Errors: res.Errors}, err
+}
@@ -11,6 +11,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,17 +28,119 @@ type BagsClient struct {
 	BaseURL   string
 	APIKey    string
 	UserAgent string
+
+	// ReadBaseURL, when set, is used instead of BaseURL for read (GET)
+	// requests, e.g. to route analytics calls to a read-optimized host.
+	// Mutations always go to BaseURL.
+	ReadBaseURL string
+
+	// ReadRetry applies to GET requests and WriteRetry to POSTs. New sets
+	// them to DefaultReadRetryPolicy and DefaultWriteRetryPolicy.
+	ReadRetry  RetryPolicy
+	WriteRetry RetryPolicy
+
+	// Blocklist, when set, is consulted by IsTokenBlocked and by
+	// CreateFeeShareConfig, which refuses blocklisted base mints. Claims are
+	// never blocked, so fees already earned on a flagged token can be
+	// withdrawn.
+	Blocklist BlocklistSource
+
+	// ImageUploader, when set, is used by CreateTokenInfoAndMetadata to
+	// upload images larger than PreUploadThreshold out of band and reference
+	// them by URL. Zero PreUploadThreshold means DefaultPreUploadThreshold.
+	ImageUploader      ImageUploader
+	PreUploadThreshold int64
+
+	// Signer, when set, supplies the default for empty LaunchWallet, Wallet,
+	// and Payer fields of launch and fee share requests, so the three cannot
+	// drift apart. Explicit values are sent unchanged.
+	Signer Signer
+
+	// PreSignHook, when set, is consulted by SignTransaction and
+	// SignTransactionBase64 before they sign, e.g. to deny unexpected
+	// programs or large transfers. Signing through Transaction methods
+	// bypasses it.
+	PreSignHook PreSignHook
+
+	// LaunchGuard, when set, detects concurrent launches of the same symbol
+	// across operators sharing its Locker.
+	LaunchGuard *LaunchGuard
+
+	// TradeFeed supplies trade events for Streams().Trades.
+	TradeFeed TradeFeed
+
+	// CandleSource supplies OHLCV candles for GetCandles.
+	CandleSource CandleSource
+
+	// ChainInspector supplies on-chain mint state for ComputeRiskSignals.
+	ChainInspector ChainInspector
+
+	// Envelopes overrides DefaultEnvelopeRule per endpoint path (e.g.
+	// "token-launch/creator/v2") for APIs that spell envelope fields
+	// differently.
+	Envelopes map[string]EnvelopeRule
+
+	// Cache, when set, serves analytics GETs from a ResponseCache.
+	Cache *ResponseCache
+
+	// ResponseHook, when set, observes every decoded response.
+	ResponseHook ResponseHook
+
+	// Identities, when set, caches the fee share wallet lookups made while
+	// resolving fee share recipients, tweet authors, and Twitter claims.
+	Identities *IdentityService
+
+	// Tenants, when set, enforces per-tenant rate and spend quotas on calls
+	// tagged with WithTenant.
+	Tenants *TenantLimiter
+
+	// Breaker enables per-endpoint circuit breaking. The zero value
+	// disables it; see BreakerPolicy.
+	Breaker BreakerPolicy
+
+	// Throttle enables adaptive client-wide throttling on high error
+	// ratios. The zero value disables it; see ThrottlePolicy.
+	Throttle ThrottlePolicy
+
+	// NumberMode selects exact (json.Number, the default) or float64
+	// decoding of untyped JSON numbers.
+	NumberMode NumberMode
+
+	// MaxClockSkew is the largest tolerated difference between the local
+	// clock and the API's, as estimated by ClockSkew. Zero means
+	// DefaultMaxClockSkew. OnClockSkew, when set, is called with the
+	// estimate each time it starts exceeding MaxClockSkew.
+	MaxClockSkew time.Duration
+	OnClockSkew  func(skew time.Duration)
+
+	// ReadyTTL is how long Ready reuses a Ping result. Zero means
+	// DefaultReadyTTL.
+	ReadyTTL time.Duration
+
+	live     atomic.Pointer[Config] // set by ApplyConfig
+	stats    sync.Map               // endpoint path -> *endpointCounters
+	breakers sync.Map               // endpoint path -> *breaker
+	ready    readyCache
+	clock    clockTracker
+	throttle throttleState
+
+	validators validatorRegistry
+
+	v2 bool // EnableV2Defaults
+
+	quoteSupport atomic.Int32 // QuoteMintSupport
 }
 
 // New creates a new BagsClient with the given API key and defaults.
-// The user-provided *http.Client is optional, and if nil will default to one with a 30s timeout.
+// The user-provided *http.Client is optional, and if nil will default to one with a 30s timeout
+// and DefaultRedirectPolicy. A provided client is used as is; see RedirectPolicy.CheckRedirect.
 func New(apiKey string, httpClient *http.Client) (*BagsClient, error) {
 	if strings.TrimSpace(apiKey) == "" {
 		return nil, errors.New("api key is required")
 	}
 	client := httpClient
 	if client == nil {
-		client = &http.Client{Timeout: 30 * time.Second}
+		client = &http.Client{Timeout: 30 * time.Second, CheckRedirect: DefaultRedirectPolicy.CheckRedirect}
 	}
 
 	return &BagsClient{
@@ -44,6 +148,9 @@ func New(apiKey string, httpClient *http.Client) (*BagsClient, error) {
 		BaseURL:   DefaultBaseURL,
 		APIKey:    apiKey,
 		UserAgent: UserAgentDefault,
+
+		ReadRetry:  DefaultReadRetryPolicy,
+		WriteRetry: DefaultWriteRetryPolicy,
 	}, nil
 }
 
@@ -81,7 +188,7 @@ func (c *BagsClient) postJSON(ctx context.Context, relPath string, body any, v a
 		}
 		rdr = buf
 	}
-	req, err := c.newRequest(ctx, http.MethodPost, relPath, rdr, "application/json")
+	req, err := c.newRequest(withRequestBody(ctx, body), http.MethodPost, relPath, rdr, "application/json")
 	if err != nil {
 		return err
 	}
@@ -89,7 +196,8 @@ func (c *BagsClient) postJSON(ctx context.Context, relPath string, body any, v a
 }
 
 func (c *BagsClient) newRequest(ctx context.Context, method, relPath string, body io.Reader, contentType string) (*http.Request, error) {
-	base, err := url.Parse(c.BaseURL)
+	cfg := c.Config()
+	base, err := url.Parse(cfg.baseURLFor(method))
 	if err != nil {
 		return nil, fmt.Errorf("parse base URL: %w", err)
 	}
@@ -108,46 +216,95 @@ func (c *BagsClient) newRequest(ctx context.Context, method, relPath string, bod
 		return nil, err
 	}
 
-	req.Header.Set("x-api-key", c.APIKey)
+	req.Header.Set("x-api-key", cfg.APIKey)
 	req.Header.Set("Accept", "application/json")
 	if contentType != "" {
 		req.Header.Set("Content-Type", contentType)
 	}
-	if ua := strings.TrimSpace(c.UserAgent); ua != "" {
+	if ua := strings.TrimSpace(cfg.UserAgent); ua != "" {
 		req.Header.Set("User-Agent", ua)
 	}
 	return req, nil
 }
 
 func (c *BagsClient) do(req *http.Request, v any) error {
-	res, err := c.HTTP.Do(req)
-	if err != nil {
+	var data []byte
+	var err error
+	if c.Cache.handles(c, req) {
+		data, err = c.Cache.body(c, req)
+	} else {
+		data, err = c.fetchBody(req, v != nil)
+	}
+	if err != nil || v == nil {
+		return err
+	}
+	if err := c.decodeJSON(data, v); err != nil {
 		return err
 	}
+	c.fireResponseHook(req, data, v)
+	return nil
+}
+
+// fetchBody sends req and returns the normalized body of a successful
+// response, or the API error. With read false the body is discarded.
+func (c *BagsClient) fetchBody(req *http.Request, read bool) ([]byte, error) {
+	res, err := c.send(req)
+	if err != nil {
+		return nil, err
+	}
 	defer res.Body.Close()
 
+	endpoint := c.endpointPath(req.URL)
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
 		var ae apiError
 		data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+		data = c.normalizeEnvelope(endpoint, res.StatusCode, data)
 		// Now checking ae.Message (field), not ae.Error (method)
 		if err := json.Unmarshal(data, &ae); err == nil && (ae.Message != "" || !ae.Success) {
+			if se := scopeError(res, endpoint, ae.Message); se != nil {
+				return nil, se
+			}
 			if ae.Status == 0 {
 				ae.Status = res.StatusCode
 			}
-			return &ae
+			return nil, &ae
+		}
+		if se := scopeError(res, endpoint, ""); se != nil {
+			return nil, se
 		}
 		bodySnippet := string(data)
 		if len(bodySnippet) > 512 {
 			bodySnippet = bodySnippet[:512] + "…"
 		}
-		return fmt.Errorf("bags api error: %s: %s", res.Status, bodySnippet)
+		return nil, fmt.Errorf("bags api error: %s: %s", res.Status, bodySnippet)
 	}
 
-	if v != nil {
-		return json.NewDecoder(res.Body).Decode(v)
+	if !read {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil, nil
 	}
-	_, _ = io.Copy(io.Discard, res.Body)
-	return nil
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	return c.normalizeEnvelope(endpoint, res.StatusCode, data), nil
+}
+
+// endpointPath returns u's path relative to the base URL, e.g.
+// "token-launch/creator/v2".
+func (c *BagsClient) endpointPath(u *url.URL) string {
+	cfg := c.Config()
+	p := u.Path
+	for _, b := range []string{cfg.ReadBaseURL, cfg.BaseURL} {
+		if b == "" {
+			continue
+		}
+		if base, err := url.Parse(b); err == nil && base.Host == u.Host {
+			p = strings.TrimPrefix(p, strings.TrimRight(base.Path, "/"))
+			break
+		}
+	}
+	return strings.Trim(p, "/")
 }
 
 type apiError struct {
@@ -0,0 +1,94 @@
+// clock.go
+package bags
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// clockSamples is the number of recent Date header readings ClockSkew takes
+// the median of.
+const clockSamples = 9
+
+// -------------------- Clock Skew --------------------
+
+// ClockSkew estimates how far the API's clock is ahead of the local clock
+// (negative when the local clock is ahead), from the Date headers of recent
+// responses. ok is false until a response with a usable Date header has been
+// seen. The estimate has roughly one-second resolution.
+func (c *BagsClient) ClockSkew() (skew time.Duration, ok bool) {
+	return c.clock.estimate()
+}
+
+// ServerNow returns the current time on the API's clock, i.e. time.Now
+// corrected by ClockSkew.
+func (c *BagsClient) ServerNow() time.Time {
+	skew, _ := c.ClockSkew()
+	return time.Now().Add(skew)
+}
+
+// WaitUntil blocks until t has passed on the API's clock, so a launch
+// scheduled for t fires on time even when the local clock is skewed. It
+// returns early with ctx's error.
+func (c *BagsClient) WaitUntil(ctx context.Context, t time.Time) error {
+	return sleepCtx(ctx, t.Sub(c.ServerNow()))
+}
+
+// ------- Internal Helpers -------
+
+type clockTracker struct {
+	mu      sync.Mutex
+	samples []time.Duration // ring of recent readings
+	next    int
+	warned  bool
+}
+
+func (t *clockTracker) estimate() (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.medianLocked()
+}
+
+func (t *clockTracker) medianLocked() (time.Duration, bool) {
+	if len(t.samples) == 0 {
+		return 0, false
+	}
+	s := slices.Clone(t.samples)
+	slices.Sort(s)
+	return s[len(s)/2], true
+}
+
+// observeClock records the skew implied by res and calls OnClockSkew when
+// the estimate first exceeds MaxClockSkew, and again after it has recovered.
+func (c *BagsClient) observeClock(res *http.Response, sent, received time.Time) {
+	if res == nil {
+		return
+	}
+	skew, ok := dateSkew(res.Header, sent, received)
+	if !ok {
+		return
+	}
+	t := &c.clock
+	t.mu.Lock()
+	if len(t.samples) < clockSamples {
+		t.samples = append(t.samples, skew)
+	} else {
+		t.samples[t.next] = skew
+		t.next = (t.next + 1) % clockSamples
+	}
+	est, _ := t.medianLocked()
+	limit := c.MaxClockSkew
+	if limit <= 0 {
+		limit = DefaultMaxClockSkew
+	}
+	fire := est.Abs() > limit && !t.warned
+	t.warned = est.Abs() > limit
+	t.mu.Unlock()
+
+	if fire && c.OnClockSkew != nil {
+		c.OnClockSkew(est)
+	}
+}
@@ -0,0 +1,454 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+// apiError is rendered as the Bags error envelope.
+type apiError struct {
+	status int
+	msg    string
+}
+
+func (e *apiError) Error() string { return e.msg }
+
+func badRequest(format string, args ...any) error {
+	return &apiError{http.StatusBadRequest, fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...any) error {
+	return &apiError{http.StatusNotFound, fmt.Sprintf(format, args...)}
+}
+
+type server struct {
+	st         *store
+	baseURL    string   // public URL of this server, used for image links
+	quoteMints []string // accepted quote mints
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	const p = "/api/v1/"
+	pong := func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"message": "pong"})
+	}
+	// BagsClient.Ping requests "/ping", which resolves against the host root.
+	mux.HandleFunc("GET /ping", pong)
+	mux.HandleFunc("GET "+p+"ping", pong)
+	mux.Handle("POST "+p+"token-launch/create-token-info", s.api(s.createTokenInfo))
+	mux.Handle("POST "+p+"token-launch/create-config", s.api(s.createLaunchConfig))
+	mux.Handle("POST "+p+"token-launch/create-launch-transaction", s.api(s.createLaunchTx))
+	mux.Handle("GET "+p+"token-launch/fee-share/wallet/twitter", s.api(s.feeShareWallet))
+	mux.Handle("POST "+p+"token-launch/fee-share/create-config", s.api(s.createFeeShareConfig))
+	mux.Handle("GET "+p+"token-launch/lifetime-fees", s.api(s.lifetimeFees))
+	mux.Handle("GET "+p+"token-launch/creator/v2", s.api(s.creators))
+	mux.Handle("GET "+p+"token-launch/claimable-positions", s.api(s.claimablePositions))
+	mux.Handle("POST "+p+"token-launch/claim-txs", s.api(s.claimTxs))
+	mux.HandleFunc("GET /images/{mint}", s.image)
+	mux.HandleFunc("GET /emulator/state", s.dump)
+	return mux
+}
+
+// api wraps an endpoint handler with the API key check and envelope encoding.
+func (s *server) api(fn func(r *http.Request) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimSpace(r.Header.Get("x-api-key")) == "" {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"success": false, "error": "missing x-api-key header"})
+			return
+		}
+		resp, err := fn(r)
+		if err != nil {
+			status := http.StatusInternalServerError
+			var ae *apiError
+			if errors.As(err, &ae) {
+				status = ae.status
+			}
+			writeJSON(w, status, map[string]any{"success": false, "error": err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"success": true, "response": resp})
+	})
+}
+
+func (s *server) createTokenInfo(r *http.Request) (any, error) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, badRequest("invalid multipart body: %v", err)
+	}
+	name, symbol := r.FormValue("name"), r.FormValue("symbol")
+	if name == "" || symbol == "" {
+		return nil, badRequest("name and symbol are required")
+	}
+	var img []byte
+	imageURL := r.FormValue("imageUrl")
+	if f, _, err := r.FormFile("image"); err == nil {
+		defer f.Close()
+		if img, err = io.ReadAll(f); err != nil {
+			return nil, badRequest("read image: %v", err)
+		}
+	} else if imageURL == "" {
+		return nil, badRequest("image or imageUrl is required")
+	}
+
+	mint, mintKey := newKeypair()
+	if imageURL == "" {
+		imageURL = s.baseURL + "/images/" + mint.String()
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	l := &launch{
+		TokenLaunchObj: bags.TokenLaunchObj{
+			UserID:       "emulator",
+			Name:         name,
+			Symbol:       symbol,
+			Description:  r.FormValue("description"),
+			Telegram:     r.FormValue("telegram"),
+			Twitter:      r.FormValue("twitter"),
+			Website:      r.FormValue("website"),
+			Image:        imageURL,
+			TokenMint:    mint.String(),
+			Status:       statusPreLaunch,
+			CreatedAtISO: now,
+			UpdatedAtISO: now,
+		},
+		MintKey: mintKey,
+	}
+	meta, _ := json.Marshal(map[string]string{
+		"name": name, "symbol": symbol, "description": l.Description, "image": l.Image,
+	})
+	l.TokenMetadata = "ipfs://" + fakeCID(meta)
+	l.URI = l.TokenMetadata
+
+	if img != nil {
+		if err := os.WriteFile(s.st.imagePath(mint.String()), img, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.st.update(func(st *state) error {
+		st.Launches[l.TokenMint] = l
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &bags.CreateTokenInfoResult{
+		TokenMint:     l.TokenMint,
+		TokenMetadata: l.TokenMetadata,
+		TokenLaunch:   l.TokenLaunchObj,
+	}, nil
+}
+
+func (s *server) createLaunchConfig(r *http.Request) (any, error) {
+	var in bags.CreateTokenLaunchConfigRequest
+	if err := decodeJSON(r, &in); err != nil {
+		return nil, err
+	}
+	if _, err := bags.ParsePublicKey(in.LaunchWallet); err != nil {
+		return nil, badRequest("invalid launchWallet: %v", err)
+	}
+	if in.QuoteMint != "" {
+		if err := s.checkQuoteMint(in.QuoteMint); err != nil {
+			return nil, err
+		}
+	}
+	key := randomKey()
+	tx, err := buildTx(in.LaunchWallet, "bags-emulator:create-config:"+key)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.st.update(func(st *state) error {
+		st.LaunchConfigs[key] = in.LaunchWallet
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return &bags.CreateTokenLaunchConfigResult{Tx: tx, ConfigKey: key}, nil
+}
+
+func (s *server) createLaunchTx(r *http.Request) (any, error) {
+	var in bags.CreateTokenLaunchTxRequest
+	if err := decodeJSON(r, &in); err != nil {
+		return nil, err
+	}
+	var tx string
+	err := s.st.update(func(st *state) error {
+		l, ok := st.Launches[in.TokenMint]
+		if !ok {
+			return notFound("unknown tokenMint %s", in.TokenMint)
+		}
+		if l.TokenMetadata != in.IPFS {
+			return badRequest("ipfs does not match token metadata")
+		}
+		_, isLaunchCfg := st.LaunchConfigs[in.ConfigKey]
+		_, isFeeShare := st.FeeShares[in.ConfigKey]
+		if !isLaunchCfg && !isFeeShare {
+			return notFound("unknown configKey %s", in.ConfigKey)
+		}
+		if l.Status != statusPreLaunch {
+			return badRequest("token %s already launched", in.TokenMint)
+		}
+		var err error
+		memo := fmt.Sprintf("bags-emulator:launch:%s:%d", in.TokenMint, in.InitialBuyLamports)
+		if tx, err = buildTx(in.Wallet, memo, ed25519.PrivateKey(l.MintKey)); err != nil {
+			return badRequest("invalid wallet: %v", err)
+		}
+		// The emulator has no chain; building the launch transaction counts
+		// as launching it.
+		l.Status, l.Ticks = statusPreGrad, 0
+		l.LaunchWallet, l.ConfigKey = in.Wallet, in.ConfigKey
+		l.UpdatedAtISO = time.Now().UTC().Format(time.RFC3339)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (s *server) feeShareWallet(r *http.Request) (any, error) {
+	handle := strings.TrimPrefix(strings.TrimSpace(r.URL.Query().Get("twitterUsername")), "@")
+	if handle == "" {
+		return nil, badRequest("twitterUsername is required")
+	}
+	handle = strings.ToLower(handle)
+	wallet := walletForHandle(handle)
+	if err := s.st.update(func(st *state) error {
+		st.TwitterWallets[handle] = wallet
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+// checkQuoteMint rejects quote mints outside -quote-mints the way the
+// wSOL-only API does.
+func (s *server) checkQuoteMint(mint string) error {
+	if !slices.Contains(s.quoteMints, mint) {
+		return badRequest("quoteMint must be %s", strings.Join(s.quoteMints, " or "))
+	}
+	return nil
+}
+
+func (s *server) createFeeShareConfig(r *http.Request) (any, error) {
+	var in bags.CreateFeeShareConfigRequest
+	if err := decodeJSON(r, &in); err != nil {
+		return nil, err
+	}
+	if in.WalletABps < 0 || in.WalletBBps < 0 || in.WalletABps+in.WalletBBps != bags.TotalBps {
+		return nil, badRequest("walletABps and walletBBps must sum to %d", bags.TotalBps)
+	}
+	if err := s.checkQuoteMint(in.QuoteMint); err != nil {
+		return nil, err
+	}
+	for _, w := range []string{in.WalletA, in.WalletB, in.Payer, in.BaseMint} {
+		if _, err := bags.ParsePublicKey(w); err != nil {
+			return nil, badRequest("invalid public key %q", w)
+		}
+	}
+
+	var res bags.CreateFeeShareConfigResult
+	err := s.st.update(func(st *state) error {
+		for key, fs := range st.FeeShares {
+			if fs.CreateFeeShareConfigRequest == in {
+				res.ConfigKey = key // already exists: no transaction needed
+				return nil
+			}
+		}
+		res.ConfigKey = randomKey()
+		var err error
+		if res.Tx, err = buildTx(in.Payer, "bags-emulator:fee-share:"+res.ConfigKey); err != nil {
+			return err
+		}
+		st.FeeShares[res.ConfigKey] = &feeShareConfig{CreateFeeShareConfigRequest: in, ConfigKey: res.ConfigKey}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (s *server) lifetimeFees(r *http.Request) (any, error) {
+	mint := r.URL.Query().Get("tokenMint")
+	var (
+		fees string
+		err  error
+	)
+	s.st.view(func(st *state) {
+		l, ok := st.Launches[mint]
+		if !ok {
+			err = notFound("unknown tokenMint %s", mint)
+			return
+		}
+		fees = fmt.Sprint(l.LifetimeFees)
+	})
+	return fees, err
+}
+
+func (s *server) creators(r *http.Request) (any, error) {
+	mint := r.URL.Query().Get("tokenMint")
+	var (
+		out []bags.TokenCreator
+		err error
+	)
+	s.st.view(func(st *state) {
+		l, ok := st.Launches[mint]
+		if !ok {
+			err = notFound("unknown tokenMint %s", mint)
+			return
+		}
+		handles := make(map[string]string, len(st.TwitterWallets))
+		for h, w := range st.TwitterWallets {
+			handles[w] = h
+		}
+		creator := func(wallet string, bps int64, isCreator bool) bags.TokenCreator {
+			h := handles[wallet]
+			return bags.TokenCreator{Username: h, TwitterUsername: h, RoyaltyBps: int(bps), IsCreator: isCreator, Wallet: wallet}
+		}
+		if fs, ok := st.FeeShares[l.ConfigKey]; ok {
+			out = append(out,
+				creator(fs.WalletA, fs.WalletABps, fs.WalletA == l.LaunchWallet),
+				creator(fs.WalletB, fs.WalletBBps, fs.WalletB == l.LaunchWallet))
+		} else if l.LaunchWallet != "" {
+			out = append(out, creator(l.LaunchWallet, bags.TotalBps, true))
+		}
+	})
+	return out, err
+}
+
+func (s *server) image(w http.ResponseWriter, r *http.Request) {
+	mint := r.PathValue("mint")
+	if _, err := bags.ParsePublicKey(mint); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeFile(w, r, s.st.imagePath(mint))
+}
+
+func (s *server) claimablePositions(r *http.Request) (any, error) {
+	wallet := r.URL.Query().Get("wallet")
+	if _, err := bags.ParsePublicKey(wallet); err != nil {
+		return nil, badRequest("invalid wallet: %v", err)
+	}
+	out := []bags.ClaimablePosition{}
+	s.st.view(func(st *state) {
+		for _, l := range st.Launches {
+			amt := st.claimable(l, wallet)
+			if amt == 0 {
+				continue
+			}
+			p := bags.ClaimablePosition{
+				BaseMint:                        l.TokenMint,
+				QuoteMint:                       bags.WrappedSOLMint,
+				VirtualPoolAddress:              derivedKey("pool:" + l.TokenMint),
+				IsMigrated:                      l.Status == statusMigrated,
+				User:                            wallet,
+				UserBps:                         st.shares(l)[wallet],
+				TotalClaimableLamportsUserShare: bags.Lamports(amt),
+			}
+			if p.IsMigrated {
+				p.DammPoolAddress = derivedKey("damm:" + l.TokenMint)
+				p.DammPoolClaimableAmount = bags.Lamports(amt)
+				p.DammPositionInfo = &bags.DammPositionInfo{
+					Position:           derivedKey("damm-position:" + l.TokenMint),
+					Pool:               p.DammPoolAddress,
+					PositionNftAccount: derivedKey("damm-nft:" + l.TokenMint),
+					TokenAMint:         l.TokenMint,
+					TokenBMint:         bags.WrappedSOLMint,
+					TokenAVault:        derivedKey("damm-vault-a:" + l.TokenMint),
+					TokenBVault:        derivedKey("damm-vault-b:" + l.TokenMint),
+				}
+			} else {
+				p.VirtualPoolClaimableAmount = bags.Lamports(amt)
+			}
+			if fs, ok := st.FeeShares[l.ConfigKey]; ok {
+				p.IsCustomFeeVault = true
+				p.CustomFeeVault = derivedKey("fee-vault:" + fs.ConfigKey)
+				p.CustomFeeVaultClaimerA, p.CustomFeeVaultClaimerB = fs.WalletA, fs.WalletB
+				p.CustomFeeVaultClaimerSide = "A"
+				if wallet == fs.WalletB {
+					p.CustomFeeVaultClaimerSide = "B"
+				}
+			}
+			out = append(out, p)
+		}
+	})
+	slices.SortFunc(out, func(a, b bags.ClaimablePosition) int { return strings.Compare(a.BaseMint, b.BaseMint) })
+	return out, nil
+}
+
+func (s *server) claimTxs(r *http.Request) (any, error) {
+	var in bags.ClaimTransactionsRequest
+	if err := decodeJSON(r, &in); err != nil {
+		return nil, err
+	}
+	var out []bags.ClaimTransaction
+	err := s.st.update(func(st *state) error {
+		l, ok := st.Launches[in.TokenMint]
+		if !ok {
+			return notFound("unknown tokenMint %s", in.TokenMint)
+		}
+		amt := st.claimable(l, in.FeeClaimer)
+		if amt == 0 {
+			return badRequest("no claimable fees for %s on %s", in.FeeClaimer, in.TokenMint)
+		}
+		b64, err := buildTx(in.FeeClaimer, fmt.Sprintf("bags-emulator:claim:%s:%d", in.TokenMint, amt))
+		if err != nil {
+			return badRequest("invalid feeClaimer: %v", err)
+		}
+		raw, _ := base64.StdEncoding.DecodeString(b64)
+		tx, _ := bags.ParseTransaction(raw)
+		var ct bags.ClaimTransaction
+		ct.Tx = bags.Base58Encode(raw)
+		ct.Blockhash.Blockhash = bags.Base58Encode(tx.Message.RecentBlockhash[:])
+		ct.Blockhash.LastValidBlockHeight = uint64(time.Now().Unix())
+		out = append(out, ct)
+		// As with launches, building the claim transaction counts as claiming.
+		st.Claimed[in.TokenMint+"/"+in.FeeClaimer] += amt
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (s *server) dump(w http.ResponseWriter, r *http.Request) {
+	s.st.view(func(st *state) {
+		writeJSON(w, http.StatusOK, st)
+	})
+}
+
+// ------- Helpers -------
+
+func decodeJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, 1<<20))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest("invalid JSON body: %v", err)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// fakeCID returns a syntactically valid CIDv0 for data.
+func fakeCID(data []byte) string {
+	sum := sha256.Sum256(data)
+	return bags.Base58Encode(append([]byte{0x12, 0x20}, sum[:]...))
+}
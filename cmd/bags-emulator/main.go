@@ -0,0 +1,96 @@
+// Command bags-emulator is a stateful local stand-in for the Bags API.
+//
+// It implements the endpoints wrapped by the SDK, persists created launches
+// and configs under -data, and advances launch statuses and accrues fees on
+// a timer so analytics calls return changing data. Any non-empty x-api-key
+// is accepted.
+//
+//	bags-emulator -addr :8787 -data ./.bags-emulator
+//	BAGS_BASE_URL=http://localhost:8787/api/v1/ BAGS_API_KEY=dev bags creators <mint>
+//
+// Returned transactions are well-formed v0 transactions carrying a memo
+// instruction; they decode and sign with the SDK but are not meant to be
+// broadcast.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8787", "listen address")
+	dataDir := flag.String("data", ".bags-emulator", "directory for persisted state and images")
+	publicURL := flag.String("public-url", "", "externally reachable base URL (default http://<addr>)")
+	tick := flag.Duration("tick", 10*time.Second, "interval between simulated status/fee updates")
+	ticksPerStatus := flag.Int("ticks-per-status", 3, "ticks a launch spends in each status before advancing")
+	feePerTick := flag.Uint64("fee-per-tick", 5_000_000, "lamports of fees accrued per tick by launched tokens")
+	quoteMints := flag.String("quote-mints", bags.WrappedSOLMint, "comma-separated quote mints accepted by config endpoints")
+	flag.Parse()
+
+	st, err := openStore(*dataDir)
+	if err != nil {
+		log.Fatalf("open state: %v", err)
+	}
+
+	base := strings.TrimRight(*publicURL, "/")
+	if base == "" {
+		host := *addr
+		if h, p, err := net.SplitHostPort(host); err == nil && h == "" {
+			host = net.JoinHostPort("localhost", p)
+		}
+		base = "http://" + host
+	}
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           logRequests((&server{st: st, baseURL: base, quoteMints: strings.Split(*quoteMints, ",")}).routes()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go func() {
+		t := time.NewTicker(*tick)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				if err := st.tick(*ticksPerStatus, *feePerTick); err != nil {
+					log.Printf("tick: %v", err)
+				}
+			}
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdown)
+	}()
+
+	log.Printf("bags emulator listening on %s (API base %s/api/v1/, state in %s)", *addr, base, *dataDir)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		log.Printf("%s %s (%s)", r.Method, r.URL.Path, time.Since(start).Round(time.Millisecond))
+	})
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+// Launch statuses, in the order the emulator advances through them.
+const (
+	statusPreLaunch = "PRE_LAUNCH"
+	statusPreGrad   = "PRE_GRAD"
+	statusMigrating = "MIGRATING"
+	statusMigrated  = "MIGRATED"
+)
+
+// launch is a token created through create-token-info.
+type launch struct {
+	bags.TokenLaunchObj
+	TokenMetadata string `json:"tokenMetadata"`
+	MintKey       []byte `json:"mintKey"` // ed25519 private key, used to co-sign the launch tx
+	ConfigKey     string `json:"configKey,omitempty"`
+	LifetimeFees  uint64 `json:"lifetimeFees"`
+	Ticks         int    `json:"ticks"` // ticks spent in the current status
+}
+
+// feeShareConfig is a config created through fee-share/create-config.
+type feeShareConfig struct {
+	bags.CreateFeeShareConfigRequest
+	ConfigKey string `json:"configKey"`
+}
+
+// state is everything the emulator persists.
+type state struct {
+	Launches       map[string]*launch         `json:"launches"`       // by token mint
+	LaunchConfigs  map[string]string          `json:"launchConfigs"`  // config key -> launch wallet
+	FeeShares      map[string]*feeShareConfig `json:"feeShares"`      // by config key
+	TwitterWallets map[string]string          `json:"twitterWallets"` // handle -> wallet
+	Claimed        map[string]uint64          `json:"claimed"`        // mint + "/" + wallet -> lamports claimed
+}
+
+// store guards state and persists it to dir after every mutation.
+type store struct {
+	mu  sync.Mutex
+	dir string
+	st  state
+}
+
+func openStore(dir string) (*store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "images"), 0o755); err != nil {
+		return nil, err
+	}
+	s := &store{dir: dir, st: state{
+		Launches:       map[string]*launch{},
+		LaunchConfigs:  map[string]string{},
+		FeeShares:      map[string]*feeShareConfig{},
+		TwitterWallets: map[string]string{},
+		Claimed:        map[string]uint64{},
+	}}
+	data, err := os.ReadFile(s.statePath())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.st); err != nil {
+		return nil, fmt.Errorf("load %s: %w", s.statePath(), err)
+	}
+	return s, nil
+}
+
+func (s *store) statePath() string { return filepath.Join(s.dir, "state.json") }
+
+func (s *store) imagePath(mint string) string { return filepath.Join(s.dir, "images", mint) }
+
+// update runs fn under the lock and persists the state when fn succeeds.
+func (s *store) update(fn func(st *state) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := fn(&s.st); err != nil {
+		return err
+	}
+	return s.saveLocked()
+}
+
+// view runs fn under the lock without persisting.
+func (s *store) view(fn func(st *state)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.st)
+}
+
+// saveLocked writes the state atomically via a temp file and rename.
+func (s *store) saveLocked() error {
+	data, err := json.MarshalIndent(&s.st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.statePath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.statePath())
+}
+
+// tick advances launch statuses and accrues fees on launched tokens. A
+// launch moves to the next status after ticksPerStatus ticks.
+func (s *store) tick(ticksPerStatus int, feePerTick uint64) error {
+	return s.update(func(st *state) error {
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, l := range st.Launches {
+			if l.Status == statusPreLaunch {
+				continue
+			}
+			l.LifetimeFees += feePerTick
+			l.Ticks++
+			if l.Ticks < ticksPerStatus {
+				continue
+			}
+			next := ""
+			switch l.Status {
+			case statusPreGrad:
+				next = statusMigrating
+			case statusMigrating:
+				next = statusMigrated
+			}
+			if next != "" {
+				l.Status, l.Ticks, l.UpdatedAtISO = next, 0, now
+			}
+		}
+		return nil
+	})
+}
+
+// ------- Helpers -------
+
+func newKeypair() (bags.PublicKey, ed25519.PrivateKey) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return bags.PublicKeyFromEd25519(pub), priv
+}
+
+func randomKey() string {
+	pk, _ := newKeypair()
+	return pk.String()
+}
+
+// walletForHandle derives a stable wallet for a Twitter handle.
+func walletForHandle(handle string) string {
+	return derivedKey("twitter:" + handle)
+}
+
+// derivedKey returns a stable public key for label, used for pool and
+// position accounts that the emulator does not otherwise track.
+func derivedKey(label string) string {
+	seed := sha256.Sum256([]byte("bags-emulator:" + label))
+	pub := ed25519.NewKeyFromSeed(seed[:]).Public().(ed25519.PublicKey)
+	return bags.PublicKeyFromEd25519(pub).String()
+}
+
+// shares returns each fee recipient's bps for a launched token.
+func (st *state) shares(l *launch) map[string]int64 {
+	if fs, ok := st.FeeShares[l.ConfigKey]; ok {
+		return map[string]int64{fs.WalletA: fs.WalletABps, fs.WalletB: fs.WalletBBps}
+	}
+	if l.LaunchWallet != "" {
+		return map[string]int64{l.LaunchWallet: bags.TotalBps}
+	}
+	return nil
+}
+
+// claimable returns the fees wallet has earned on l and not yet claimed.
+func (st *state) claimable(l *launch, wallet string) uint64 {
+	earned := l.LifetimeFees * uint64(st.shares(l)[wallet]) / uint64(bags.TotalBps)
+	return earned - min(earned, st.Claimed[l.TokenMint+"/"+wallet])
+}
+
+// memoProgram is the SPL memo program, used as the sole instruction in
+// emulated transactions.
+var memoProgram, _ = bags.ParsePublicKey("MemoSq4gqABAXKb96qnH8TysNcWxMyWCqXgDLGmfcHr")
+
+// buildTx returns a base64 v0 transaction paid by payer that carries memo.
+// cosigners are additional required signers whose keys sign immediately.
+func buildTx(payer string, memo string, cosigners ...ed25519.PrivateKey) (string, error) {
+	fp, err := bags.ParsePublicKey(payer)
+	if err != nil {
+		return "", err
+	}
+	keys := []bags.PublicKey{fp}
+	for _, k := range cosigners {
+		keys = append(keys, bags.PublicKeyFromEd25519(k.Public().(ed25519.PublicKey)))
+	}
+	nsig := len(keys)
+	keys = append(keys, memoProgram)
+
+	tx := &bags.Transaction{
+		Signatures: make([]bags.Signature, nsig),
+		Message: bags.Message{
+			Versioned: true,
+			Header: bags.MessageHeader{
+				NumRequiredSignatures:       uint8(nsig),
+				NumReadonlySignedAccounts:   uint8(nsig - 1),
+				NumReadonlyUnsignedAccounts: 1,
+			},
+			AccountKeys:  keys,
+			Instructions: []bags.CompiledInstruction{{ProgramIDIndex: uint8(nsig), Data: []byte(memo)}},
+		},
+	}
+	_, _ = rand.Read(tx.Message.RecentBlockhash[:])
+	if len(cosigners) > 0 {
+		if err := tx.PartialSign(cosigners...); err != nil {
+			return "", err
+		}
+	}
+	return tx.Base64()
+}
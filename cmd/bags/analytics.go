@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func runPing(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("ping", "ping [flags]")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	if err := c.Ping(ctx); err != nil {
+		return err
+	}
+	return g.emit(map[string]string{"message": "pong"}, func(w io.Writer) { row(w, "pong") })
+}
+
+func runFees(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "lifetime" {
+		return fmt.Errorf("usage: bags fees lifetime [flags] <mint>...")
+	}
+	fs, g := newFlagSet("fees lifetime", "fees lifetime [flags] <mint>...")
+	concurrency := fs.Int("concurrency", bags.DefaultBatchConcurrency, "max concurrent requests")
+	args, err := parse(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fs.Usage()
+		return fmt.Errorf("at least one mint is required")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	res, err := c.GetTokenLifetimeFeesBatch(ctx, args, bags.BatchOptions{Concurrency: *concurrency})
+	var be *bags.BatchError
+	if err != nil && !errors.As(err, &be) {
+		return err
+	}
+
+	type feeRow struct {
+		Mint     string        `json:"mint"`
+		Lamports bags.Lamports `json:"lamports,omitempty"`
+		SOL      string        `json:"sol,omitempty"`
+		Error    string        `json:"error,omitempty"`
+	}
+	var rows []feeRow
+	for mint, fees := range res.Results {
+		rows = append(rows, feeRow{Mint: mint, Lamports: fees, SOL: fees.SOLString()})
+	}
+	for mint, err := range res.Errors {
+		rows = append(rows, feeRow{Mint: mint, Error: err.Error()})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Mint < rows[j].Mint })
+
+	if err := g.emit(rows, func(w io.Writer) {
+		row(w, "MINT", "LAMPORTS", "SOL", "ERROR")
+		for _, r := range rows {
+			if r.Error != "" {
+				row(w, r.Mint, "", "", r.Error)
+				continue
+			}
+			row(w, r.Mint, r.Lamports, r.SOL, "")
+		}
+	}); err != nil {
+		return err
+	}
+	if be != nil {
+		return fmt.Errorf("%d of %d mints failed", len(be.Items), be.Total)
+	}
+	return nil
+}
+
+func runCreators(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("creators", "creators [flags] <mint>")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return fmt.Errorf("exactly one mint is required")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	creators, err := c.GetTokenLaunchCreators(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return g.emit(creators, func(w io.Writer) {
+		row(w, "USERNAME", "TWITTER", "WALLET", "ROYALTY_BPS", "CREATOR")
+		for _, cr := range creators {
+			row(w, cr.Username, cr.TwitterUsername, cr.Wallet, cr.RoyaltyBps, cr.IsCreator)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func runClaim(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("claim", "claim [flags] <twitter-handle>")
+	var mints stringList
+	fs.Var(&mints, "mint", "token mint to claim (repeatable; default all claimable)")
+	build := fs.Bool("build", false, "build claim transactions instead of listing balances")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return fmt.Errorf("exactly one Twitter handle is required")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+
+	if !*build {
+		claims, err := c.GetClaimablesByTwitter(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		return g.emit(claims, func(w io.Writer) {
+			row(w, "MINT", "CLAIMABLE_SOL", "BPS", "MIGRATED")
+			for _, p := range claims.Positions {
+				row(w, p.BaseMint, p.Claimable().SOLString(), p.UserBps, p.IsMigrated)
+			}
+			row(w, "total", claims.Total.SOLString(), "", "")
+		})
+	}
+
+	res, err := c.CreateClaimTransactionsByTwitter(ctx, args[0], mints, bags.BatchOptions{})
+	var be *bags.BatchError
+	if err != nil && !errors.As(err, &be) {
+		return err
+	}
+	type txRow struct {
+		Mint  string `json:"mint"`
+		Tx    string `json:"tx,omitempty"`
+		Error string `json:"error,omitempty"`
+	}
+	var rows []txRow
+	for mint, txs := range res.Transactions {
+		for _, tx := range txs {
+			rows = append(rows, txRow{Mint: mint, Tx: tx.Tx})
+		}
+	}
+	for mint, err := range res.Errors {
+		rows = append(rows, txRow{Mint: mint, Error: err.Error()})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].Mint < rows[j].Mint })
+	if err := g.emit(rows, func(w io.Writer) {
+		row(w, "MINT", "TX", "ERROR")
+		for _, r := range rows {
+			row(w, r.Mint, r.Tx, r.Error)
+		}
+	}); err != nil {
+		return err
+	}
+	if be != nil {
+		return fmt.Errorf("%d of %d mints failed", len(be.Items), be.Total)
+	}
+	return nil
+}
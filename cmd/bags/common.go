@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+// globalFlags are accepted by every subcommand.
+type globalFlags struct {
+	apiKey   string
+	baseURL  string
+	output   string
+	cacheDir string
+}
+
+func newFlagSet(name, usage string) (*flag.FlagSet, *globalFlags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	g := &globalFlags{}
+	fs.StringVar(&g.apiKey, "api-key", "", "Bags API key (env BAGS_API_KEY)")
+	fs.StringVar(&g.baseURL, "base-url", "", "API base URL (env BAGS_BASE_URL)")
+	fs.StringVar(&g.cacheDir, "cache-dir", "", "cache analytics responses in this directory (env BAGS_CACHE_DIR)")
+	fs.StringVar(&g.output, "output", "table", "output format: table, json, or ndjson")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: bags %s\n\nflags:\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs, g
+}
+
+func (g *globalFlags) client() (*bags.BagsClient, error) {
+	c, err := bags.New(envOr(g.apiKey, "BAGS_API_KEY"), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w (set --api-key or BAGS_API_KEY)", err)
+	}
+	if u := envOr(g.baseURL, "BAGS_BASE_URL"); u != "" {
+		c.BaseURL = u
+	}
+	if dir := envOr(g.cacheDir, "BAGS_CACHE_DIR"); dir != "" {
+		store, err := bags.NewFileStateStore(dir)
+		if err != nil {
+			return nil, fmt.Errorf("cache dir: %w", err)
+		}
+		c.Cache = bags.NewResponseCache(store, 0, 0)
+	}
+	return c, nil
+}
+
+// parse parses args allowing flags after positional arguments, and returns
+// the positional arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos, nil
+		}
+		if args[0] == "--" {
+			return append(pos, args[1:]...), nil
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
+
+// emit writes v as JSON, as NDJSON with one line per element when v is a
+// slice, or calls table to render rows when the table format is selected.
+func (g *globalFlags) emit(v any, table func(w io.Writer)) error {
+	switch g.output {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "ndjson":
+		nw := bags.NewNDJSONWriter(os.Stdout, nil)
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			for i := range rv.Len() {
+				if err := nw.Write(rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			return nw.Flush()
+		}
+		if err := nw.Write(v); err != nil {
+			return err
+		}
+		return nw.Flush()
+	case "table", "":
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", g.output)
+	}
+}
+
+func row(w io.Writer, cols ...any) {
+	parts := make([]string, len(cols))
+	for i, c := range cols {
+		parts[i] = fmt.Sprint(c)
+	}
+	fmt.Fprintln(w, strings.Join(parts, "\t"))
+}
+
+// envOr returns v, falling back to the environment variable key when v is empty.
+func envOr(v, key string) string {
+	if v != "" {
+		return v
+	}
+	return os.Getenv(key)
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string     { return strings.Join(*s, ",") }
+func (s *stringList) Set(v string) error { *s = append(*s, v); return nil }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func runDoctor(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("doctor", "doctor [flags]")
+	rpcURL := fs.String("rpc-url", "", "Solana JSON-RPC URL to check (env SOLANA_RPC_URL)")
+	keypair := fs.String("keypair", "", "keypair file of the signer to check")
+	maxSkew := fs.Duration("max-clock-skew", bags.DefaultMaxClockSkew, "largest tolerated clock difference to the API")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	_, key, err := loadSigner(*keypair)
+	if err != nil {
+		return err
+	}
+	if key != nil {
+		c.Signer = bags.KeypairSigner(key)
+	}
+
+	report := c.SelfTest(ctx, &bags.SelfTestOptions{
+		RPCURL:       envOr(*rpcURL, "SOLANA_RPC_URL"),
+		MaxClockSkew: *maxSkew,
+	})
+	if err := g.emit(report, func(w io.Writer) {
+		row(w, "STATUS", "CHECK", "DETAIL", "FIX")
+		for _, ck := range report.Checks {
+			row(w, ck.Status, ck.Name, ck.Detail, ck.Fix)
+		}
+	}); err != nil {
+		return err
+	}
+	if !report.OK() {
+		return fmt.Errorf("self-test failed")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func runFeeShare(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: bags feeshare <wallet|create-config> [flags]")
+	}
+	switch args[0] {
+	case "wallet":
+		return runFeeShareWallet(ctx, args[1:])
+	case "create-config":
+		return runFeeShareCreateConfig(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown feeshare command %q", args[0])
+	}
+}
+
+func runFeeShareWallet(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("feeshare wallet", "feeshare wallet [flags] <twitter-username>")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return fmt.Errorf("exactly one twitter username is required")
+	}
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	handle := strings.TrimPrefix(args[0], "@")
+	wallet, err := c.GetFeeShareWallet(ctx, handle)
+	if err != nil {
+		return err
+	}
+	return g.emit(map[string]string{"twitterUsername": handle, "wallet": wallet}, func(w io.Writer) {
+		row(w, "TWITTER", "WALLET")
+		row(w, handle, wallet)
+	})
+}
+
+func runFeeShareCreateConfig(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("feeshare create-config",
+		"feeshare create-config --base-mint <mint> --recipient <wallet|@handle>:<bps> ... [flags]")
+	var recipients stringList
+	fs.Var(&recipients, "recipient", "fee recipient as <wallet>:<bps> or @<twitter>:<bps> (repeatable, at most two)")
+	baseMint := fs.String("base-mint", "", "token mint the fees apply to")
+	payer := fs.String("payer", "", "payer wallet (defaults to the --keypair public key)")
+	quoteMint := fs.String("quote-mint", bags.WrappedSOLMint, "quote mint")
+	keypair := fs.String("keypair", "", "keypair file used to sign the returned transaction")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+
+	signer, signerKey, err := loadSigner(*keypair)
+	if err != nil {
+		return err
+	}
+	if *payer == "" {
+		*payer = signer
+	}
+	if *baseMint == "" || *payer == "" || len(recipients) == 0 {
+		fs.Usage()
+		return fmt.Errorf("--base-mint, --recipient, and --payer (or --keypair) are required")
+	}
+
+	b := bags.NewFeeShareConfigBuilder(*baseMint, *payer).QuoteMint(*quoteMint)
+	for _, r := range recipients {
+		who, bps, err := parseRecipient(r)
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(who, "@") {
+			b.Twitter(who, bps)
+		} else {
+			b.Wallet(who, bps)
+		}
+	}
+	if err := b.Validate(); err != nil {
+		return err
+	}
+
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	if signerKey != nil {
+		c.Signer = bags.KeypairSigner(signerKey)
+	}
+	res, err := b.Create(ctx, c)
+	if err != nil {
+		return err
+	}
+	if signerKey != nil && res.Tx != "" {
+		if res.Tx, err = c.SignTransactionBase64(ctx, res.Tx); err != nil {
+			return fmt.Errorf("sign fee share transaction: %w", err)
+		}
+	}
+	return g.emit(res, func(w io.Writer) {
+		row(w, "CONFIG_KEY", res.ConfigKey)
+		row(w, "TX", res.Tx)
+	})
+}
+
+// parseRecipient splits "<who>:<bps>".
+func parseRecipient(s string) (string, int64, error) {
+	i := strings.LastIndexByte(s, ':')
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid recipient %q, want <wallet|@handle>:<bps>", s)
+	}
+	bps, err := strconv.ParseInt(s[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid recipient bps in %q: %w", s, err)
+	}
+	return s[:i], bps, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func runLaunch(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("launch",
+		"launch --image <file> --name <name> --symbol <symbol> (--keypair <file> | --wallet <pubkey>) [flags]")
+	image := fs.String("image", "", "token image file")
+	name := fs.String("name", "", "token name")
+	symbol := fs.String("symbol", "", "token symbol")
+	description := fs.String("description", "", "token description")
+	twitter := fs.String("twitter", "", "twitter URL")
+	telegram := fs.String("telegram", "", "telegram URL")
+	website := fs.String("website", "", "website URL")
+	keypair := fs.String("keypair", "", "launch wallet keypair file; signs the returned transactions")
+	wallet := fs.String("wallet", "", "launch wallet public key (when not signing locally)")
+	configKey := fs.String("config-key", "", "existing launch config key; skips config creation")
+	initialBuy := fs.Float64("initial-buy-sol", 0, "initial buy in SOL")
+	lockDir := fs.String("lock-dir", "", "shared directory for launch locks; rejects concurrent launches of a symbol (env BAGS_LOCK_DIR)")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %v", args)
+	}
+
+	signer, signerKey, err := loadSigner(*keypair)
+	if err != nil {
+		return err
+	}
+	if *wallet == "" {
+		*wallet = signer
+	}
+	if *image == "" || *name == "" || *symbol == "" || *wallet == "" {
+		fs.Usage()
+		return fmt.Errorf("--image, --name, --symbol, and --keypair or --wallet are required")
+	}
+	buy, err := bags.LamportsFromSOL(*initialBuy)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(*image)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	if signerKey != nil {
+		c.Signer = bags.KeypairSigner(signerKey)
+	}
+	if dir := envOr(*lockDir, "BAGS_LOCK_DIR"); dir != "" {
+		c.LaunchGuard = &bags.LaunchGuard{Locker: &bags.FileLocker{Dir: dir}}
+		defer c.ReleaseLaunch(context.WithoutCancel(ctx), *symbol)
+	}
+
+	type launchOutput struct {
+		TokenMint     string `json:"tokenMint"`
+		TokenMetadata string `json:"tokenMetadata"`
+		ConfigKey     string `json:"configKey"`
+		ConfigTx      string `json:"configTx,omitempty"`
+		LaunchTx      string `json:"launchTx"`
+		Signed        bool   `json:"signed"`
+	}
+	out := launchOutput{Signed: signerKey != nil}
+
+	info, err := c.CreateTokenInfoAndMetadata(ctx, &bags.CreateTokenInfoRequest{
+		Name:          *name,
+		Symbol:        *symbol,
+		Description:   *description,
+		Telegram:      *telegram,
+		Twitter:       *twitter,
+		Website:       *website,
+		Image:         f,
+		ImageFilename: filepath.Base(*image),
+		ImageMIMEType: mime.TypeByExtension(filepath.Ext(*image)),
+	})
+	if err != nil {
+		return fmt.Errorf("create token info: %w", err)
+	}
+	out.TokenMint, out.TokenMetadata = info.TokenMint, info.TokenMetadata
+
+	out.ConfigKey = *configKey
+	if out.ConfigKey == "" {
+		cfg, err := c.CreateTokenLaunchConfig(ctx, &bags.CreateTokenLaunchConfigRequest{LaunchWallet: *wallet})
+		if err != nil {
+			return fmt.Errorf("create launch config: %w", err)
+		}
+		out.ConfigKey, out.ConfigTx = cfg.ConfigKey, cfg.Tx
+		if signerKey != nil && out.ConfigTx != "" {
+			if out.ConfigTx, err = c.SignTransactionBase64(ctx, out.ConfigTx); err != nil {
+				return fmt.Errorf("sign config transaction: %w", err)
+			}
+		}
+	}
+
+	tx, err := c.CreateTokenLaunchTransaction(ctx, &bags.CreateTokenLaunchTxRequest{
+		IPFS:               info.TokenMetadata,
+		TokenMint:          info.TokenMint,
+		Wallet:             *wallet,
+		InitialBuyLamports: buy,
+		ConfigKey:          out.ConfigKey,
+	})
+	if err != nil {
+		return fmt.Errorf("create launch transaction: %w", err)
+	}
+	out.LaunchTx = tx.Transaction
+	if signerKey != nil {
+		if out.LaunchTx, err = c.SignTransactionBase64(ctx, out.LaunchTx); err != nil {
+			return fmt.Errorf("sign launch transaction: %w", err)
+		}
+	}
+
+	return g.emit(out, func(w io.Writer) {
+		row(w, "TOKEN_MINT", out.TokenMint)
+		row(w, "METADATA", out.TokenMetadata)
+		row(w, "CONFIG_KEY", out.ConfigKey)
+		if out.ConfigTx != "" {
+			row(w, "CONFIG_TX", out.ConfigTx)
+		}
+		row(w, "LAUNCH_TX", out.LaunchTx)
+		row(w, "SIGNED", out.Signed)
+	})
+}
+
+// loadSigner reads a keypair file, returning its address and key. An empty
+// path yields no signer.
+func loadSigner(path string) (string, ed25519.PrivateKey, error) {
+	if path == "" {
+		return "", nil, nil
+	}
+	key, err := bags.ReadKeypairFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return bags.PublicKeyFromEd25519(key.Public().(ed25519.PublicKey)).String(), key, nil
+}
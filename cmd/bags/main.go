@@ -0,0 +1,89 @@
+// Command bags is a command-line client for the Bags API.
+//
+// Usage:
+//
+//	bags <command> [flags] [args]
+//
+// Commands:
+//
+//	ping                     verify API connectivity
+//	doctor                   check key, connectivity, clock, RPC, and signer before a launch
+//	launch                   upload metadata and build a token launch
+//	launch-tweet <url>       launch a token from a tweet, sharing fees with its author
+//	fees lifetime <mint>...  lifetime fees for one or more mints
+//	creators <mint>          launch creators of a mint
+//	feeshare wallet <handle> fee share wallet of a Twitter user
+//	feeshare create-config   create a fee share config
+//	claim <handle>           claimable fees and claim transactions of a Twitter user
+//
+// The API key is read from --api-key or the BAGS_API_KEY environment variable.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"ping", "verify API connectivity", runPing},
+		{"doctor", "check key, connectivity, clock, RPC, and signer before a launch", runDoctor},
+		{"launch", "upload metadata and build a token launch", runLaunch},
+		{"launch-tweet", "launch a token from a tweet, sharing fees with its author", runLaunchTweet},
+		{"fees", "fee analytics (lifetime)", runFees},
+		{"creators", "launch creators of a mint", runCreators},
+		{"feeshare", "fee share wallet lookup and config creation", runFeeShare},
+		{"claim", "claimable fees and claim transactions of a Twitter user", runClaim},
+	}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, "bags:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(os.Stderr)
+		return flag.ErrHelp
+	}
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(ctx, args[1:])
+		}
+	}
+	usage(os.Stderr)
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: bags <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'bags <command> -h' for command flags.")
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func runLaunchTweet(ctx context.Context, args []string) error {
+	fs, g := newFlagSet("launch-tweet",
+		"launch-tweet <tweet-url> (--fetcher-url <url> | --tweet-json <file>) (--keypair <file> | --wallet <pubkey>) [flags]")
+	fetcherURL := fs.String("fetcher-url", "", "tweet fetcher service; GET <url>?url=<tweet-url> returns the tweet as JSON (env BAGS_TWEET_FETCHER_URL)")
+	tweetJSON := fs.String("tweet-json", "", "read the tweet from this JSON file instead (- for stdin)")
+	keypair := fs.String("keypair", "", "launch wallet keypair file; signs the returned transactions")
+	wallet := fs.String("wallet", "", "launch wallet public key (when not signing locally)")
+	name := fs.String("name", "", "token name (default derived from the tweet)")
+	symbol := fs.String("symbol", "", "token symbol (default the first cashtag or initials)")
+	description := fs.String("description", "", "token description (default the tweet text)")
+	authorBps := fs.Int64("author-bps", bags.TotalBps, "fee share of the tweet author in bps; 0 keeps every fee")
+	initialBuy := fs.Float64("initial-buy-sol", 0, "initial buy in SOL")
+	lockDir := fs.String("lock-dir", "", "shared directory for launch locks; rejects concurrent launches of a symbol (env BAGS_LOCK_DIR)")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		fs.Usage()
+		return fmt.Errorf("exactly one tweet URL is required")
+	}
+
+	var fetcher bags.TweetFetcher
+	switch u := envOr(*fetcherURL, "BAGS_TWEET_FETCHER_URL"); {
+	case *tweetJSON != "":
+		tw, err := readTweet(*tweetJSON)
+		if err != nil {
+			return err
+		}
+		fetcher = bags.TweetFetcherFunc(func(context.Context, string) (*bags.Tweet, error) { return tw, nil })
+	case u != "":
+		fetcher = &bags.HTTPTweetFetcher{URL: u}
+	default:
+		fs.Usage()
+		return fmt.Errorf("--fetcher-url or --tweet-json is required")
+	}
+
+	signer, signerKey, err := loadSigner(*keypair)
+	if err != nil {
+		return err
+	}
+	if *wallet == "" {
+		*wallet = signer
+	}
+	if *wallet == "" {
+		fs.Usage()
+		return fmt.Errorf("--keypair or --wallet is required")
+	}
+	buy, err := bags.LamportsFromSOL(*initialBuy)
+	if err != nil {
+		return err
+	}
+
+	c, err := g.client()
+	if err != nil {
+		return err
+	}
+	if signerKey != nil {
+		c.Signer = bags.KeypairSigner(signerKey)
+	}
+	if dir := envOr(*lockDir, "BAGS_LOCK_DIR"); dir != "" {
+		c.LaunchGuard = &bags.LaunchGuard{Locker: &bags.FileLocker{Dir: dir}}
+	}
+
+	res, err := c.LaunchFromTweet(ctx, args[0], &bags.LaunchFromTweetOptions{
+		Fetcher:            fetcher,
+		Wallet:             *wallet,
+		Name:               *name,
+		Symbol:             *symbol,
+		Description:        *description,
+		InitialBuyLamports: buy,
+		AuthorBps:          authorBps,
+	})
+	if err != nil {
+		return err
+	}
+
+	type launchTweetOutput struct {
+		TokenMint     string `json:"tokenMint"`
+		TokenMetadata string `json:"tokenMetadata"`
+		AuthorWallet  string `json:"authorWallet"`
+		AuthorBps     int64  `json:"authorBps"`
+		ConfigKey     string `json:"configKey"`
+		FeeShareTx    string `json:"feeShareTx,omitempty"`
+		LaunchTx      string `json:"launchTx"`
+		Signed        bool   `json:"signed"`
+	}
+	out := launchTweetOutput{
+		TokenMint:     res.TokenInfo.TokenMint,
+		TokenMetadata: res.TokenInfo.TokenMetadata,
+		AuthorWallet:  res.AuthorWallet,
+		AuthorBps:     *authorBps,
+		ConfigKey:     res.FeeShare.ConfigKey,
+		FeeShareTx:    res.FeeShare.Tx,
+		LaunchTx:      res.Transaction.Transaction,
+		Signed:        signerKey != nil,
+	}
+	if signerKey != nil {
+		if out.FeeShareTx != "" {
+			if out.FeeShareTx, err = c.SignTransactionBase64(ctx, out.FeeShareTx); err != nil {
+				return fmt.Errorf("sign fee share transaction: %w", err)
+			}
+		}
+		if out.LaunchTx, err = c.SignTransactionBase64(ctx, out.LaunchTx); err != nil {
+			return fmt.Errorf("sign launch transaction: %w", err)
+		}
+	}
+
+	return g.emit(out, func(w io.Writer) {
+		row(w, "TOKEN_MINT", out.TokenMint)
+		row(w, "METADATA", out.TokenMetadata)
+		row(w, "AUTHOR_WALLET", out.AuthorWallet)
+		row(w, "AUTHOR_BPS", out.AuthorBps)
+		row(w, "CONFIG_KEY", out.ConfigKey)
+		if out.FeeShareTx != "" {
+			row(w, "FEE_SHARE_TX", out.FeeShareTx)
+		}
+		row(w, "LAUNCH_TX", out.LaunchTx)
+		row(w, "SIGNED", out.Signed)
+	})
+}
+
+// readTweet decodes a bags.Tweet from a JSON file, or stdin for "-".
+func readTweet(path string) (*bags.Tweet, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	var tw bags.Tweet
+	if err := json.NewDecoder(r).Decode(&tw); err != nil {
+		return nil, fmt.Errorf("decode tweet: %w", err)
+	}
+	return &tw, nil
+}
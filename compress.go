@@ -0,0 +1,171 @@
+// compress.go
+package bags
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrStateCorrupt is returned by CompressedStateStore.Load when a value
+// fails its integrity check.
+var ErrStateCorrupt = errors.New("state corrupt")
+
+// stateMagic prefixes values written by CompressedStateStore.
+const stateMagic = "BAGS\x01"
+
+// -------------------- Compression --------------------
+
+// Codec compresses persisted state. GzipCodec is built in; other formats
+// such as zstd can be plugged in by implementing Codec over a third-party
+// package.
+type Codec interface {
+	// Name identifies the codec in stored values, e.g. "gzip". At most 255
+	// bytes.
+	Name() string
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// GzipCodec compresses with gzip at Level; zero means gzip.DefaultCompression.
+type GzipCodec struct {
+	Level int
+}
+
+func (GzipCodec) Name() string { return "gzip" }
+
+func (g GzipCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	level := g.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	return gzip.NewWriterLevel(w, level)
+}
+
+func (GzipCodec) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// CompressedStateStore wraps a StateStore, compressing values with Codec
+// and storing a SHA-256 checksum to detect corruption on read. Values that
+// were written without the wrapper are returned unchanged, so an existing
+// store can be wrapped in place.
+//
+// It works for any StateStore user, including stream cursors and a
+// ResponseCache.
+type CompressedStateStore struct {
+	Store StateStore
+	// Codec compresses new values. Nil stores them uncompressed, with a
+	// checksum only.
+	Codec Codec
+	// Decoders are additional codecs accepted on read, e.g. the previous
+	// Codec while migrating to a new one.
+	Decoders []Codec
+}
+
+// NewCompressedStateStore wraps store with codec.
+func NewCompressedStateStore(store StateStore, codec Codec) *CompressedStateStore {
+	return &CompressedStateStore{Store: store, Codec: codec}
+}
+
+func (s *CompressedStateStore) Load(ctx context.Context, key string) ([]byte, error) {
+	raw, err := s.Store.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(raw, []byte(stateMagic)) {
+		return raw, nil
+	}
+	v, err := s.decode(raw[len(stateMagic):])
+	if err != nil {
+		return nil, fmt.Errorf("load state %q: %w", key, err)
+	}
+	return v, nil
+}
+
+func (s *CompressedStateStore) Save(ctx context.Context, key string, value []byte) error {
+	var buf bytes.Buffer
+	buf.WriteString(stateMagic)
+	name := ""
+	if s.Codec != nil {
+		name = s.Codec.Name()
+	}
+	if len(name) > 255 {
+		return fmt.Errorf("codec name %q too long", name)
+	}
+	buf.WriteByte(byte(len(name)))
+	buf.WriteString(name)
+	sum := sha256.Sum256(value)
+	buf.Write(sum[:])
+	if s.Codec == nil {
+		buf.Write(value)
+	} else {
+		w, err := s.Codec.NewWriter(&buf)
+		if err != nil {
+			return fmt.Errorf("save state %q: %w", key, err)
+		}
+		if _, err := w.Write(value); err != nil {
+			return fmt.Errorf("save state %q: %w", key, err)
+		}
+		if err := w.Close(); err != nil {
+			return fmt.Errorf("save state %q: %w", key, err)
+		}
+	}
+	return s.Store.Save(ctx, key, buf.Bytes())
+}
+
+func (s *CompressedStateStore) Delete(ctx context.Context, key string) error {
+	return s.Store.Delete(ctx, key)
+}
+
+// ------- Internal Helpers -------
+
+// decode parses a value after the magic: codec name length, codec name,
+// checksum of the uncompressed value, and the payload.
+func (s *CompressedStateStore) decode(b []byte) ([]byte, error) {
+	if len(b) < 1 || len(b) < 1+int(b[0])+sha256.Size {
+		return nil, fmt.Errorf("%w: truncated header", ErrStateCorrupt)
+	}
+	name := string(b[1 : 1+b[0]])
+	b = b[1+len(name):]
+	sum, payload := b[:sha256.Size], b[sha256.Size:]
+
+	v := payload
+	if name != "" {
+		codec := s.codec(name)
+		if codec == nil {
+			return nil, fmt.Errorf("no decoder for codec %q", name)
+		}
+		r, err := codec.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrStateCorrupt, err)
+		}
+		defer r.Close()
+		if v, err = io.ReadAll(r); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrStateCorrupt, err)
+		}
+	}
+	if got := sha256.Sum256(v); !bytes.Equal(got[:], sum) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrStateCorrupt)
+	}
+	return v, nil
+}
+
+func (s *CompressedStateStore) codec(name string) Codec {
+	if s.Codec != nil && s.Codec.Name() == name {
+		return s.Codec
+	}
+	for _, c := range s.Decoders {
+		if c.Name() == name {
+			return c
+		}
+	}
+	if name == (GzipCodec{}).Name() {
+		return GzipCodec{}
+	}
+	return nil
+}
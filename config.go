@@ -0,0 +1,330 @@
+// config.go
+package bags
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Config holds the client settings that can be swapped at runtime with
+// ApplyConfig or WatchConfigFile. Tenant quotas (BagsClient.Tenants) are not
+// part of it; adjust them with TenantLimiter.SetQuota.
+type Config struct {
+	APIKey      string
+	BaseURL     string
+	ReadBaseURL string // optional; see BagsClient.ReadBaseURL
+	UserAgent   string
+	ReadRetry   RetryPolicy
+	WriteRetry  RetryPolicy
+	Breaker     BreakerPolicy
+	Throttle    ThrottlePolicy
+}
+
+// Config returns the settings currently in effect.
+func (c *BagsClient) Config() Config {
+	if live := c.live.Load(); live != nil {
+		return *live
+	}
+	return Config{
+		APIKey:      c.APIKey,
+		BaseURL:     c.BaseURL,
+		ReadBaseURL: c.ReadBaseURL,
+		UserAgent:   c.UserAgent,
+		ReadRetry:   c.ReadRetry,
+		WriteRetry:  c.WriteRetry,
+		Breaker:     c.Breaker,
+		Throttle:    c.Throttle,
+	}
+}
+
+// baseURLFor returns the base URL for requests using method.
+func (cfg Config) baseURLFor(method string) string {
+	if cfg.ReadBaseURL != "" && isReadMethod(method) {
+		return cfg.ReadBaseURL
+	}
+	return cfg.BaseURL
+}
+
+// Validate checks that cfg is usable by a client.
+func (cfg Config) Validate() error {
+	if strings.TrimSpace(cfg.APIKey) == "" {
+		return errors.New("api key is required")
+	}
+	if err := validateBaseURL(cfg.BaseURL); err != nil {
+		return err
+	}
+	if cfg.ReadBaseURL != "" {
+		if err := validateBaseURL(cfg.ReadBaseURL); err != nil {
+			return fmt.Errorf("read %w", err)
+		}
+	}
+	for name, p := range map[string]RetryPolicy{"readRetry": cfg.ReadRetry, "writeRetry": cfg.WriteRetry} {
+		if p.MaxAttempts < 0 || p.MaxAttempts > 20 {
+			return fmt.Errorf("%s.maxAttempts must be between 0 and 20, got %d", name, p.MaxAttempts)
+		}
+		if p.BaseDelay < 0 || p.MaxDelay < 0 {
+			return fmt.Errorf("%s delays must not be negative", name)
+		}
+	}
+	if cfg.Breaker.FailureThreshold < 0 || cfg.Breaker.OpenTimeout < 0 {
+		return errors.New("breaker.failureThreshold and breaker.openTimeout must not be negative")
+	}
+	t := cfg.Throttle
+	if t.ErrorThreshold < 0 || t.ErrorThreshold >= 1 {
+		return fmt.Errorf("throttle.errorThreshold must be in [0, 1), got %v", t.ErrorThreshold)
+	}
+	if t.Window < 0 || t.MinSamples < 0 || t.MinRate < 0 {
+		return errors.New("throttle.window, throttle.minSamples, and throttle.minRate must not be negative")
+	}
+	return nil
+}
+
+func validateBaseURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("base URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("base URL must be an absolute http(s) URL, got %q", raw)
+	}
+	return nil
+}
+
+// ApplyConfig validates cfg and atomically swaps it in for all subsequent
+// requests. In-flight requests finish with the settings they started with.
+//
+// Once a config has been applied, the client's exported APIKey, BaseURL,
+// ReadBaseURL, UserAgent, ReadRetry, WriteRetry, Breaker, and Throttle fields
+// are no longer consulted. Breaker and throttle state carry over; a new
+// policy applies to the next attempt.
+func (c *BagsClient) ApplyConfig(cfg Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if c.v2 {
+		cfg = cfg.withV2Defaults()
+	}
+	c.live.Store(&cfg)
+	return nil
+}
+
+// -------------------- Config Files --------------------
+
+// LoadConfigFile reads a JSON config file on top of base, then applies the
+// BAGS_API_KEY, BAGS_BASE_URL, and BAGS_READ_BASE_URL environment overrides. Fields missing from
+// the file keep their value from base. Example:
+//
+//	{
+//	  "apiKey": "<YOUR_API_KEY>",
+//	  "baseUrl": "https://public-api-v2.bags.fm/api/v1/",
+//	  "readBaseUrl": "",
+//	  "userAgent": "my-launcher/1.0",
+//	  "readRetry": {"maxAttempts": 4, "baseDelay": "250ms", "maxDelay": "5s"},
+//	  "writeRetry": {"maxAttempts": 1},
+//	  "breaker": {"failureThreshold": 5, "openTimeout": "30s"},
+//	  "throttle": {"errorThreshold": 0.5, "window": "1m", "minSamples": 20, "minRate": 1}
+//	}
+func LoadConfigFile(path string, base Config) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(data, base)
+}
+
+func parseConfig(data []byte, base Config) (Config, error) {
+	var f struct {
+		APIKey      *string          `json:"apiKey"`
+		BaseURL     *string          `json:"baseUrl"`
+		ReadBaseURL *string          `json:"readBaseUrl"`
+		UserAgent   *string          `json:"userAgent"`
+		ReadRetry   *retryPolicyJSON `json:"readRetry"`
+		WriteRetry  *retryPolicyJSON `json:"writeRetry"`
+		Breaker     *struct {
+			FailureThreshold *int    `json:"failureThreshold"`
+			OpenTimeout      *string `json:"openTimeout"`
+		} `json:"breaker"`
+		Throttle *struct {
+			ErrorThreshold *float64 `json:"errorThreshold"`
+			Window         *string  `json:"window"`
+			MinSamples     *int     `json:"minSamples"`
+			MinRate        *float64 `json:"minRate"`
+		} `json:"throttle"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return Config{}, fmt.Errorf("parse config: %w", err)
+	}
+	cfg := base
+	if f.APIKey != nil {
+		cfg.APIKey = *f.APIKey
+	}
+	if f.BaseURL != nil {
+		cfg.BaseURL = *f.BaseURL
+	}
+	if f.ReadBaseURL != nil {
+		cfg.ReadBaseURL = *f.ReadBaseURL
+	}
+	if f.UserAgent != nil {
+		cfg.UserAgent = *f.UserAgent
+	}
+	if f.ReadRetry != nil {
+		if err := f.ReadRetry.apply(&cfg.ReadRetry); err != nil {
+			return Config{}, fmt.Errorf("parse config: readRetry: %w", err)
+		}
+	}
+	if f.WriteRetry != nil {
+		if err := f.WriteRetry.apply(&cfg.WriteRetry); err != nil {
+			return Config{}, fmt.Errorf("parse config: writeRetry: %w", err)
+		}
+	}
+	if b := f.Breaker; b != nil {
+		if b.FailureThreshold != nil {
+			cfg.Breaker.FailureThreshold = *b.FailureThreshold
+		}
+		if err := parseDuration(b.OpenTimeout, &cfg.Breaker.OpenTimeout); err != nil {
+			return Config{}, fmt.Errorf("parse config: breaker: %w", err)
+		}
+	}
+	if t := f.Throttle; t != nil {
+		if t.ErrorThreshold != nil {
+			cfg.Throttle.ErrorThreshold = *t.ErrorThreshold
+		}
+		if t.MinSamples != nil {
+			cfg.Throttle.MinSamples = *t.MinSamples
+		}
+		if t.MinRate != nil {
+			cfg.Throttle.MinRate = *t.MinRate
+		}
+		if err := parseDuration(t.Window, &cfg.Throttle.Window); err != nil {
+			return Config{}, fmt.Errorf("parse config: throttle: %w", err)
+		}
+	}
+	if v := os.Getenv("BAGS_API_KEY"); v != "" {
+		cfg.APIKey = v
+	}
+	if v := os.Getenv("BAGS_BASE_URL"); v != "" {
+		cfg.BaseURL = v
+	}
+	if v := os.Getenv("BAGS_READ_BASE_URL"); v != "" {
+		cfg.ReadBaseURL = v
+	}
+	return cfg, nil
+}
+
+type retryPolicyJSON struct {
+	MaxAttempts *int    `json:"maxAttempts"`
+	BaseDelay   *string `json:"baseDelay"`
+	MaxDelay    *string `json:"maxDelay"`
+}
+
+func (r *retryPolicyJSON) apply(p *RetryPolicy) error {
+	if r.MaxAttempts != nil {
+		p.MaxAttempts = *r.MaxAttempts
+	}
+	if err := parseDuration(r.BaseDelay, &p.BaseDelay); err != nil {
+		return err
+	}
+	return parseDuration(r.MaxDelay, &p.MaxDelay)
+}
+
+// parseDuration sets *dst from src unless src is nil.
+func parseDuration(src *string, dst *time.Duration) error {
+	if src == nil {
+		return nil
+	}
+	v, err := time.ParseDuration(*src)
+	if err != nil {
+		return err
+	}
+	*dst = v
+	return nil
+}
+
+// WatchOptions configures WatchConfigFile.
+type WatchOptions struct {
+	// Interval between checks for changes. Zero means 2s.
+	Interval time.Duration
+	// OnReload is called after a changed config has been applied.
+	OnReload func(Config)
+	// OnError is called when a changed file cannot be read, parsed, or
+	// validated. The previous config stays in effect.
+	OnError func(error)
+}
+
+// WatchConfigFile loads path with LoadConfigFile, applies it, and then polls
+// the file for changes until ctx is done, hot-swapping each valid revision.
+//
+// The initial load is synchronous: an unreadable or invalid file is returned
+// as an error and nothing is watched. Later failures are reported through
+// opts.OnError and leave the last good config in place.
+func (c *BagsClient) WatchConfigFile(ctx context.Context, path string, opts *WatchOptions) error {
+	var o WatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = 2 * time.Second
+	}
+
+	base := c.Config()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	cfg, err := parseConfig(data, base)
+	if err == nil {
+		err = c.ApplyConfig(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("load %s: %w", path, err)
+	}
+	last := sha256.Sum256(data)
+
+	go func() {
+		t := time.NewTicker(o.Interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				if o.OnError != nil {
+					o.OnError(err)
+				}
+				continue
+			}
+			sum := sha256.Sum256(data)
+			if sum == last {
+				continue
+			}
+			last = sum
+			cfg, err := parseConfig(data, base)
+			if err == nil {
+				err = c.ApplyConfig(cfg)
+			}
+			if err != nil {
+				if o.OnError != nil {
+					o.OnError(fmt.Errorf("reload %s: %w", path, err))
+				}
+				continue
+			}
+			if o.OnReload != nil {
+				o.OnReload(cfg)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,167 @@
+// decimal.go
+package bags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// -------------------- Decimal Numbers --------------------
+
+// Decimal is an exact base-10 number, used for prices and other amounts
+// that must not pick up float64 rounding. The zero value is 0.
+//
+// It keeps the text it was parsed from, so values round-trip through JSON
+// unchanged. It unmarshals from a JSON number or a numeric string.
+type Decimal struct {
+	s string // canonical number text; empty means 0
+}
+
+// Bounds on the numbers ParseDecimal accepts. Exact comparisons expand the
+// value, so unbounded input such as "1e1000000000" would exhaust memory.
+const (
+	MaxDecimalDigits   = 100 // significant digits, before and after the point
+	MaxDecimalExponent = 400 // absolute value of the exponent
+)
+
+// ParseDecimal parses a decimal number such as "0.000001234" or "1.5e-9",
+// with at most MaxDecimalDigits digits and an exponent within
+// ±MaxDecimalExponent.
+func ParseDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Decimal{}, fmt.Errorf("empty decimal")
+	}
+	// Only JSON number syntax, so the text can be marshaled back verbatim.
+	if c := s[0]; (c != '-' && (c < '0' || c > '9')) || !json.Valid([]byte(s)) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	mant, exp, _ := strings.Cut(strings.ToLower(s), "e")
+	if digits := len(mant) - strings.Count(mant, "-") - strings.Count(mant, "."); digits > MaxDecimalDigits {
+		return Decimal{}, fmt.Errorf("decimal has %d digits, more than %d", digits, MaxDecimalDigits)
+	}
+	if exp != "" {
+		if e, err := strconv.Atoi(exp); err != nil || e > MaxDecimalExponent || e < -MaxDecimalExponent {
+			return Decimal{}, fmt.Errorf("decimal exponent %s is out of range", exp)
+		}
+	}
+	return Decimal{s: s}, nil
+}
+
+// DecimalFromFloat returns the shortest decimal that round-trips to f. NaN
+// and infinities yield 0.
+func DecimalFromFloat(f float64) Decimal {
+	if f == 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+	return Decimal{s: strconv.FormatFloat(f, 'g', -1, 64)}
+}
+
+// RatDecimal returns r as a Decimal with the given number of fractional
+// digits, rounding half away from zero.
+func RatDecimal(r *big.Rat, prec int) Decimal {
+	return Decimal{s: r.FloatString(prec)}
+}
+
+// Rat returns the exact value.
+func (d Decimal) Rat() *big.Rat {
+	r := new(big.Rat)
+	if d.s != "" {
+		r.SetString(d.s)
+	}
+	return r
+}
+
+// Float64 returns the nearest float64.
+func (d Decimal) Float64() float64 {
+	if d.s == "" {
+		return 0
+	}
+	f, _ := strconv.ParseFloat(d.s, 64)
+	return f
+}
+
+// Cmp compares d and e exactly, returning -1, 0, or +1.
+func (d Decimal) Cmp(e Decimal) int {
+	if d.s == e.s {
+		return 0
+	}
+	return d.Rat().Cmp(e.Rat())
+}
+
+// IsZero reports whether d equals 0.
+func (d Decimal) IsZero() bool { return d.s == "" || d.Rat().Sign() == 0 }
+
+// String returns the number as it was parsed, or "0".
+func (d Decimal) String() string {
+	if d.s == "" {
+		return "0"
+	}
+	return d.s
+}
+
+// MarshalJSON encodes the value as a JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding one.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// -------------------- Number Mode --------------------
+
+// NumberMode selects how the client decodes JSON numbers that land in
+// untyped values (any, map[string]any), e.g. in the results of NewQuery
+// callbacks built on raw responses. Typed amounts (Lamports, Decimal, bps)
+// are exact in either mode.
+type NumberMode int
+
+const (
+	// NumberExact decodes untyped numbers as json.Number, keeping every
+	// digit. It is the default.
+	NumberExact NumberMode = iota
+	// NumberFloat64 decodes untyped numbers as float64, which is faster but
+	// rounds values beyond 53 bits of precision.
+	NumberFloat64
+)
+
+// ------- Internal Helpers -------
+
+// decodeJSON unmarshals data into v according to the client's NumberMode.
+func (c *BagsClient) decodeJSON(data []byte, v any) error {
+	if c.NumberMode == NumberFloat64 {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after top-level value")
+	}
+	return nil
+}
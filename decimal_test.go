@@ -0,0 +1,79 @@
+// decimal_test.go
+package bags_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in string
+		ok bool
+	}{
+		{"0", true},
+		{"-1.5", true},
+		{"0.000001234", true},
+		{"1.5e-9", true},
+		{"2E+10", true},
+		{"1e400", true},
+		{"1e-400", true},
+		{"0." + strings.Repeat("1", bags.MaxDecimalDigits-1), true},
+
+		{"", false},
+		{"abc", false},
+		{"+1", false},
+		{".5", false},
+		{"1.", false},
+		{"0x10", false},
+		{"NaN", false},
+		{"1e401", false},
+		{"1e-401", false},
+		{"1e1000000000", false},
+		{"1e99999999999999999999", false},
+		{strings.Repeat("9", bags.MaxDecimalDigits+1), false},
+		{"0." + strings.Repeat("0", bags.MaxDecimalDigits) + "1", false},
+	}
+	for _, tt := range tests {
+		_, err := bags.ParseDecimal(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("ParseDecimal(%.40q) error = %v, want ok = %v", tt.in, err, tt.ok)
+		}
+	}
+}
+
+func TestDecimalUnmarshalJSONBounds(t *testing.T) {
+	var v struct {
+		Price bags.Decimal `json:"price"`
+	}
+	for _, in := range []string{`{"price":1e1000000000}`, `{"price":"1e1000000000"}`} {
+		if err := json.Unmarshal([]byte(in), &v); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded, want an error", in)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"price":"0.25"}`), &v); err != nil || v.Price.String() != "0.25" {
+		t.Errorf("Unmarshal of a string price = %v, %v", v.Price, err)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1", "1.0", 0},
+		{"1.5e-9", "0.0000000015", 0},
+		{"0.1", "0.2", -1},
+		{"-1", "-2", 1},
+	}
+	for _, tt := range tests {
+		a, _ := bags.ParseDecimal(tt.a)
+		b, _ := bags.ParseDecimal(tt.b)
+		if got := a.Cmp(b); got != tt.want {
+			t.Errorf("%s.Cmp(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// defaults.go
+package bags
+
+import (
+	"net/http"
+	"time"
+)
+
+// -------------------- V2 Defaults --------------------
+
+// V2WriteRetryPolicy is WriteRetry under EnableV2Defaults. POSTs are then
+// retried only when the API rate limited them (429), since such requests
+// were not applied and cannot be duplicated by a retry.
+var V2WriteRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// V2BreakerPolicy is Breaker under EnableV2Defaults.
+var V2BreakerPolicy = BreakerPolicy{FailureThreshold: 5, OpenTimeout: 30 * time.Second}
+
+// V2ThrottlePolicy is Throttle under EnableV2Defaults.
+var V2ThrottlePolicy = ThrottlePolicy{ErrorThreshold: 0.5, Window: time.Minute}
+
+// EnableV2Defaults opts c into the defaults planned for the next major
+// version, so production deployments can migrate before they change:
+//
+//   - WriteRetry becomes V2WriteRetryPolicy, and POSTs are retried on 429
+//     only, whatever WriteRetry allows.
+//   - Breaker becomes V2BreakerPolicy.
+//   - Throttle becomes V2ThrottlePolicy.
+//
+// Settings changed from their current defaults are kept. The policies are
+// applied to the live config as well, and to every config passed to
+// ApplyConfig or loaded by WatchConfigFile afterwards. Typed time fields are
+// not part of V2: timestamps such as TokenLaunchObj.CreatedAtISO stay
+// strings. Call it right after New, before the client is shared.
+func (c *BagsClient) EnableV2Defaults() {
+	c.v2 = true
+	c.WriteRetry, c.Breaker, c.Throttle = v2Policies(c.WriteRetry, c.Breaker, c.Throttle)
+	if live := c.live.Load(); live != nil {
+		cfg := live.withV2Defaults()
+		c.live.Store(&cfg)
+	}
+}
+
+// V2Defaults reports whether EnableV2Defaults was called on c.
+func (c *BagsClient) V2Defaults() bool { return c.v2 }
+
+// ------- Internal Helpers -------
+
+// retryableWrite reports whether the V2 rules allow retrying a failed
+// attempt of req.
+func (c *BagsClient) retryableWrite(req *http.Request, res *http.Response) bool {
+	if !c.v2 || isReadMethod(req.Method) {
+		return true
+	}
+	return res != nil && res.StatusCode == http.StatusTooManyRequests
+}
+
+// withV2Defaults returns cfg with the V2 policies in place of defaults.
+func (cfg Config) withV2Defaults() Config {
+	cfg.WriteRetry, cfg.Breaker, cfg.Throttle = v2Policies(cfg.WriteRetry, cfg.Breaker, cfg.Throttle)
+	return cfg
+}
+
+func v2Policies(w RetryPolicy, b BreakerPolicy, t ThrottlePolicy) (RetryPolicy, BreakerPolicy, ThrottlePolicy) {
+	if w == DefaultWriteRetryPolicy {
+		w = V2WriteRetryPolicy
+	}
+	if b == (BreakerPolicy{}) {
+		b = V2BreakerPolicy
+	}
+	if t == (ThrottlePolicy{}) {
+		t = V2ThrottlePolicy
+	}
+	return w, b, t
+}
@@ -0,0 +1,145 @@
+// defaults_test.go
+package bags_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+// failingServer answers every request with status and Retry-After: 0, and
+// counts the attempts it saw.
+func failingServer(t *testing.T, status int) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var hits atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"success":false,"error":"unavailable"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func testClient(t *testing.T, srv *httptest.Server, v2 bool) *bags.BagsClient {
+	t.Helper()
+	c, err := bags.New("test-key", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.BaseURL = srv.URL + "/api/v1/"
+	if v2 {
+		c.EnableV2Defaults()
+	}
+	return c
+}
+
+func TestWriteRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		v2     bool
+		status int
+		want   int64
+	}{
+		{"v1 503 sent once", false, http.StatusServiceUnavailable, 1},
+		{"v1 429 sent once", false, http.StatusTooManyRequests, 1},
+		{"v2 503 sent once", true, http.StatusServiceUnavailable, 1},
+		{"v2 429 retried", true, http.StatusTooManyRequests, int64(bags.V2WriteRetryPolicy.MaxAttempts)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, hits := failingServer(t, tt.status)
+			c := testClient(t, srv, tt.v2)
+			if _, err := c.CreateTokenLaunchConfig(context.Background(), &bags.CreateTokenLaunchConfigRequest{LaunchWallet: testWallet}); err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := hits.Load(); got != tt.want {
+				t.Errorf("attempts = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRetryUnchangedByV2(t *testing.T) {
+	for _, v2 := range []bool{false, true} {
+		srv, hits := failingServer(t, http.StatusServiceUnavailable)
+		c := testClient(t, srv, v2)
+		_ = c.Ping(context.Background())
+		if got, want := hits.Load(), int64(bags.DefaultReadRetryPolicy.MaxAttempts); got != want {
+			t.Errorf("v2=%v: attempts = %d, want %d", v2, got, want)
+		}
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	for _, v2 := range []bool{false, true} {
+		srv, hits := failingServer(t, http.StatusServiceUnavailable)
+		c := testClient(t, srv, v2)
+		c.ReadRetry = bags.RetryPolicy{MaxAttempts: 1}
+		var open bool
+		for range bags.V2BreakerPolicy.FailureThreshold + 2 {
+			err := c.Ping(context.Background())
+			open = open || errors.Is(err, bags.ErrCircuitOpen)
+		}
+		if open != v2 {
+			t.Errorf("v2=%v: breaker opened = %v", v2, open)
+		}
+		want := int64(bags.V2BreakerPolicy.FailureThreshold + 2)
+		if v2 {
+			want = int64(bags.V2BreakerPolicy.FailureThreshold)
+		}
+		if got := hits.Load(); got != want {
+			t.Errorf("v2=%v: attempts = %d, want %d", v2, got, want)
+		}
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	for _, v2 := range []bool{false, true} {
+		srv, _ := failingServer(t, http.StatusServiceUnavailable)
+		c := testClient(t, srv, false)
+		c.ReadRetry = bags.RetryPolicy{MaxAttempts: 1}
+		c.Breaker = bags.BreakerPolicy{FailureThreshold: 1000} // keep every attempt on the wire
+		if v2 {
+			c.EnableV2Defaults()
+		}
+		for range 20 {
+			_ = c.Ping(context.Background())
+		}
+		if got := c.ThrottleStatus().Throttled; got != v2 {
+			t.Errorf("v2=%v: throttled = %v", v2, got)
+		}
+	}
+}
+
+func TestV2DefaultsFollowConfig(t *testing.T) {
+	srv, _ := failingServer(t, http.StatusServiceUnavailable)
+	base := bags.Config{APIKey: "test-key", BaseURL: srv.URL + "/api/v1/", WriteRetry: bags.DefaultWriteRetryPolicy}
+
+	// Enabled after a config is live.
+	c := testClient(t, srv, false)
+	if err := c.ApplyConfig(base); err != nil {
+		t.Fatal(err)
+	}
+	c.EnableV2Defaults()
+	if got := c.Config(); got.WriteRetry != bags.V2WriteRetryPolicy || got.Breaker != bags.V2BreakerPolicy || got.Throttle != bags.V2ThrottlePolicy {
+		t.Errorf("live config after EnableV2Defaults = %+v", got)
+	}
+
+	// Configs applied later keep the V2 policies, and explicit settings win.
+	custom := base
+	custom.Breaker = bags.BreakerPolicy{FailureThreshold: 2}
+	if err := c.ApplyConfig(custom); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Config(); got.WriteRetry != bags.V2WriteRetryPolicy || got.Breaker != custom.Breaker || got.Throttle != bags.V2ThrottlePolicy {
+		t.Errorf("config after ApplyConfig = %+v", got)
+	}
+}
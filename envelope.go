@@ -0,0 +1,118 @@
+// envelope.go
+package bags
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// -------------------- Envelope Compatibility --------------------
+
+// EnvelopeRule lists, in priority order, the field names an endpoint may
+// use for the envelope payload and error message. Past API revisions have
+// used "data" for the payload and "message" for errors.
+type EnvelopeRule struct {
+	Response []string
+	Error    []string
+}
+
+// DefaultEnvelopeRule applies to endpoints without an entry in
+// BagsClient.Envelopes.
+var DefaultEnvelopeRule = EnvelopeRule{
+	Response: []string{"response", "data", "result"},
+	Error:    []string{"error", "message", "msg"},
+}
+
+// normalizeEnvelope rewrites a JSON envelope to the current spelling
+// ({"success", "response", "error"}) so the typed decoders only need to know
+// one shape:
+//
+//   - "response", when missing or null, is taken from the first non-null
+//     Response field;
+//   - "success", when missing, is inferred from the status alone, so
+//     {"data": {...}, "message": "Created"} on a 2xx succeeds;
+//   - on failure, "error" is taken from the first Error field holding a
+//     string, or an object with a "message" string.
+//
+// Existing fields are kept. Bodies that are not envelopes (no "success", no
+// payload field, and no error field on a failed status) are returned
+// unchanged.
+func (c *BagsClient) normalizeEnvelope(endpoint string, status int, data []byte) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return data
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &obj); err != nil {
+		return data
+	}
+	rule, ok := c.Envelopes[endpoint]
+	if !ok {
+		rule = DefaultEnvelopeRule
+	}
+
+	payload := firstField(obj, rule.Response)
+	msg, hasMsg := "", false
+	for _, name := range rule.Error {
+		if msg, hasMsg = errorMessage(obj[name]); hasMsg {
+			break
+		}
+	}
+	failed := status < 200 || status >= 300
+	_, hasSuccess := obj["success"]
+	if !hasSuccess && payload == nil && !(failed && hasMsg) {
+		return data
+	}
+	changed := false
+	if payload != nil && firstField(obj, []string{"response"}) == nil {
+		obj["response"] = payload
+		changed = true
+	}
+	if hasSuccess {
+		var ok bool
+		failed = json.Unmarshal(obj["success"], &ok) != nil || !ok
+	} else {
+		obj["success"], _ = json.Marshal(!failed)
+		changed = true
+	}
+	var cur string
+	if failed && hasMsg && (json.Unmarshal(obj["error"], &cur) != nil || cur != msg) {
+		obj["error"], _ = json.Marshal(msg)
+		changed = true
+	}
+	if !changed {
+		return data
+	}
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func firstField(obj map[string]json.RawMessage, names []string) json.RawMessage {
+	for _, name := range names {
+		if v, ok := obj[name]; ok && !bytes.Equal(bytes.TrimSpace(v), []byte("null")) {
+			return v
+		}
+	}
+	return nil
+}
+
+// errorMessage extracts a message from a string or {"message": string}.
+func errorMessage(raw json.RawMessage) (string, bool) {
+	if len(raw) == 0 {
+		return "", false
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s, s != ""
+	}
+	var o struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(raw, &o) == nil && o.Message != "" {
+		return o.Message, true
+	}
+	return "", false
+}
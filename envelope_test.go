@@ -0,0 +1,67 @@
+// envelope_test.go
+package bags_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func TestEnvelopeNormalization(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		rules   map[string]bags.EnvelopeRule
+		want    bags.Lamports
+		wantErr string // substring of the error; empty means success
+	}{
+		{"current envelope", 200, `{"success":true,"response":"5"}`, nil, 5, ""},
+		{"data payload", 200, `{"data":"5"}`, nil, 5, ""},
+		{"result payload", 200, `{"success":true,"result":"5"}`, nil, 5, ""},
+		{"data with message on 2xx", 200, `{"data":"5","message":"Created"}`, nil, 5, ""},
+		{"null response falls through", 200, `{"response":null,"data":"5"}`, nil, 5, ""},
+		{"endpoint rule", 200, `{"amount":"7"}`, map[string]bags.EnvelopeRule{
+			"token-launch/lifetime-fees": {Response: []string{"amount"}},
+		}, 7, ""},
+
+		{"explicit failure on 2xx", 200, `{"success":false,"error":"nope"}`, nil, 0, "unexpected response"},
+		{"success not a bool", 200, `{"success":"yes","response":"5"}`, nil, 0, "success"},
+		{"message on 4xx", 400, `{"message":"bad mint"}`, nil, 0, "bad mint"},
+		{"nested error object", 400, `{"error":{"message":"nested"}}`, nil, 0, "nested"},
+		{"msg on 4xx", 400, `{"msg":"short"}`, nil, 0, "short"},
+		{"empty error skipped", 400, `{"error":"","message":"fallback"}`, nil, 0, "fallback"},
+		{"non-JSON error", 400, `oops`, nil, 0, "oops"},
+		{"truncated JSON", 200, `{"success":true,"response":`, nil, 0, "unexpected EOF"},
+		{"array body", 200, `["5"]`, nil, 0, "cannot unmarshal"},
+		{"empty body", 200, ``, nil, 0, "EOF"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			c := testClient(t, srv, false)
+			c.ReadRetry = bags.RetryPolicy{MaxAttempts: 1}
+			c.Envelopes = tt.rules
+
+			got, err := c.GetTokenLifetimeFeesLamports(context.Background(), testMint)
+			if tt.wantErr == "" {
+				if err != nil || got != tt.want {
+					t.Errorf("got %v, %v; want %v", got, err, tt.want)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
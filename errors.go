@@ -0,0 +1,105 @@
+// errors.go
+package bags
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrInsufficientScope matches (via errors.Is) a ScopeError returned when the
+// API key is valid but not permitted to call an endpoint.
+var ErrInsufficientScope = errors.New("api key lacks required scope")
+
+// API scope names inferred for endpoints when the API does not name one.
+const (
+	ScopeAnalytics = "analytics"
+	ScopeFeeShare  = "fee-share"
+	ScopeLaunch    = "launch"
+)
+
+// ScopeError reports that the API key lacks the scope required by an endpoint.
+type ScopeError struct {
+	// Scope is the missing scope. When the API response does not name it,
+	// Scope is inferred from the endpoint and Inferred is true.
+	Scope    string
+	Inferred bool
+	Endpoint string
+	Status   int
+	Message  string
+}
+
+func (e *ScopeError) Error() string {
+	msg := fmt.Sprintf("bags api error (%d): api key lacks scope %q for %s", e.Status, e.Scope, e.Endpoint)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is reports whether target is ErrInsufficientScope.
+func (e *ScopeError) Is(target error) bool { return target == ErrInsufficientScope }
+
+// ------- Internal Helpers -------
+
+var (
+	scopeNameRe = regexp.MustCompile(`(?i)\bscopes?\b\s*(?:[:=]\s*["']?|["'])([a-z][\w:.\-]*)`)
+	scopeHintRe = regexp.MustCompile(`(?i)\b(scope|permission|not (?:allowed|permitted|authorized) to|forbidden for this (?:api )?key)\b`)
+)
+
+// scopeError inspects a 401/403 response and returns a ScopeError when it
+// indicates missing permissions rather than a bad or missing key. A 401 is
+// only a scope error when WWW-Authenticate says insufficient_scope; the
+// scope headers and message heuristics are applied to 403 alone.
+func scopeError(res *http.Response, endpoint, message string) *ScopeError {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusUnauthorized {
+		return nil
+	}
+	se := &ScopeError{Endpoint: endpoint, Status: res.StatusCode, Message: message}
+
+	// RFC 6750: WWW-Authenticate: Bearer error="insufficient_scope", scope="analytics"
+	if wa := res.Header.Get("WWW-Authenticate"); strings.Contains(wa, "insufficient_scope") {
+		if m := scopeNameRe.FindStringSubmatch(wa); m != nil {
+			se.Scope = m[1]
+		}
+	} else if res.StatusCode != http.StatusForbidden {
+		return nil
+	} else if h := firstHeader(res.Header, "X-Required-Scope", "X-Missing-Scope"); h != "" {
+		se.Scope = h
+	} else if scopeHintRe.MatchString(message) {
+		if m := scopeNameRe.FindStringSubmatch(message); m != nil {
+			se.Scope = m[1]
+		}
+	} else {
+		return nil
+	}
+
+	if se.Scope == "" {
+		se.Scope, se.Inferred = endpointScope(endpoint), true
+	}
+	return se
+}
+
+// endpointScope maps an endpoint path to the scope it most likely requires.
+func endpointScope(endpoint string) string {
+	switch {
+	case strings.HasPrefix(endpoint, "token-launch/lifetime-fees"),
+		strings.HasPrefix(endpoint, "token-launch/creator"):
+		return ScopeAnalytics
+	case strings.HasPrefix(endpoint, "token-launch/fee-share"):
+		return ScopeFeeShare
+	default:
+		return ScopeLaunch
+	}
+}
+
+func firstHeader(h http.Header, keys ...string) string {
+	for _, k := range keys {
+		if v := strings.TrimSpace(h.Get(k)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
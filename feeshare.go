@@ -101,7 +101,7 @@ type CreateFeeShareConfigRequest struct {
 	WalletBBps int64  `json:"walletBBps"` // Basis points for walletB (0-10000)
 	Payer      string `json:"payer"`      // Payer wallet public key
 	BaseMint   string `json:"baseMint"`   // Token mint public key
-	QuoteMint  string `json:"quoteMint"`  // Quote mint public key (must be wSOL mint at the moment)
+	QuoteMint  string `json:"quoteMint"`  // Quote mint public key (wSOL unless the API supports others; see QuoteMintSupport)
 }
 
 // CreateFeeShareConfigResult matches the Bags response "response" payload.
@@ -133,10 +133,16 @@ type CreateFeeShareConfigResult struct {
 // Error responses:
 //
 //	400/401/500: {"success": false, "error": "<string>"}
+//
+// An empty Payer defaults to the client's Signer. A non-wSOL QuoteMint
+// rejected by the API fails with a *QuoteMintError.
 func (c *BagsClient) CreateFeeShareConfig(ctx context.Context, in *CreateFeeShareConfigRequest) (*CreateFeeShareConfigResult, error) {
 	if in == nil {
 		return nil, fmt.Errorf("nil request")
 	}
+	req := *in
+	req.Payer = c.signerWallet(req.Payer)
+	in = &req
 	// Minimal validation; the API ultimately enforces correctness.
 	if strings.TrimSpace(in.WalletA) == "" ||
 		strings.TrimSpace(in.WalletB) == "" ||
@@ -145,12 +151,22 @@ func (c *BagsClient) CreateFeeShareConfig(ctx context.Context, in *CreateFeeShar
 		strings.TrimSpace(in.QuoteMint) == "" {
 		return nil, fmt.Errorf("walletA, walletB, payer, baseMint, and quoteMint are required")
 	}
+	if err := validateQuoteMint(in.QuoteMint, in.BaseMint); err != nil {
+		return nil, err
+	}
+	if err := c.validators.run(in); err != nil {
+		return nil, err
+	}
+	if err := c.checkTokenAllowed(ctx, in.BaseMint); err != nil {
+		return nil, err
+	}
 
 	var env struct {
 		Success  bool                        `json:"success"`
 		Response *CreateFeeShareConfigResult `json:"response"`
 	}
-	if err := c.postJSON(ctx, "token-launch/fee-share/create-config", in, &env); err != nil {
+	err := c.postJSON(ctx, "token-launch/fee-share/create-config", in, &env)
+	if err := c.observeQuoteMint("token-launch/fee-share/create-config", in.QuoteMint, err); err != nil {
 		return nil, err
 	}
 	if !env.Success || env.Response == nil {
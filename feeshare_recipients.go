@@ -0,0 +1,218 @@
+// feeshare_recipients.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TotalBps is the basis-point total a fee share allocation must sum to (100%).
+const TotalBps int64 = 10000
+
+// WrappedSOLMint is the wSOL mint, the default quote mint and the only one
+// accepted by API versions without alternative quote mint support.
+const WrappedSOLMint = "So11111111111111111111111111111111111111112"
+
+// MaxFeeShareRecipients is the most recipients the create-config endpoint
+// accepts.
+const MaxFeeShareRecipients = 2
+
+// ErrUnsupportedRecipients matches a RecipientCountError with errors.Is.
+var ErrUnsupportedRecipients = errors.New("unsupported fee share recipient count")
+
+// -------------------- Fee Share Recipients --------------------
+
+// FeeShareRecipient is a single fee share allocation.
+//
+// Exactly one of Wallet or TwitterUsername should be set. A Wallet value
+// prefixed with "@" is treated as a Twitter username. Twitter usernames are
+// resolved to wallets via GetFeeShareWallet before the request is sent.
+type FeeShareRecipient struct {
+	Wallet          string // Wallet address (base58 public key)
+	TwitterUsername string // Twitter username/handle (with or without @)
+	Bps             int64  // Basis points allocated to this recipient (0-10000)
+}
+
+// RecipientCountError reports a recipient list longer than the
+// walletA/walletB pair the create-config endpoint accepts.
+type RecipientCountError struct {
+	Count int
+}
+
+func (e *RecipientCountError) Error() string {
+	return fmt.Sprintf("fee share config supports at most %d recipients, got %d", MaxFeeShareRecipients, e.Count)
+}
+
+func (e *RecipientCountError) Is(target error) bool { return target == ErrUnsupportedRecipients }
+
+// FeeShareRecipientsRequest describes a fee share config as a list of one or
+// two recipients instead of the fixed walletA/walletB pair used on the wire.
+//
+// The recipients' Bps must sum to TotalBps. QuoteMint defaults to
+// WrappedSOLMint when empty.
+type FeeShareRecipientsRequest struct {
+	Recipients []FeeShareRecipient
+	Payer      string // Payer wallet public key (defaults to the client's Signer)
+	BaseMint   string // Token mint public key
+	QuoteMint  string // Quote mint public key (defaults to wSOL)
+}
+
+// CreateFeeShareConfigFromRecipients validates a recipient list, resolves any Twitter
+// usernames to fee share wallets, and submits it as a fee share config.
+//
+// The create-config endpoint accepts at most two recipients
+// (walletA/walletB); longer lists fail with a *RecipientCountError before
+// any network call is made.
+func (c *BagsClient) CreateFeeShareConfigFromRecipients(ctx context.Context, in *FeeShareRecipientsRequest) (*CreateFeeShareConfigResult, error) {
+	if in != nil {
+		// Before recipients are resolved over the network.
+		if err := c.validators.run(in); err != nil {
+			return nil, err
+		}
+	}
+	req, err := c.buildFeeShareConfigRequest(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateFeeShareConfig(ctx, req)
+}
+
+// buildFeeShareConfigRequest maps a recipient list down to the wire format. A
+// single recipient is sent as walletA with TotalBps and walletB as the same
+// wallet with 0 bps.
+func (c *BagsClient) buildFeeShareConfigRequest(ctx context.Context, in *FeeShareRecipientsRequest) (*CreateFeeShareConfigRequest, error) {
+	if in == nil {
+		return nil, fmt.Errorf("nil request")
+	}
+	if err := validateFeeShareRecipients(in.Recipients); err != nil {
+		return nil, err
+	}
+	wallets := make([]string, len(in.Recipients))
+	for i, r := range in.Recipients {
+		w, err := c.resolveFeeShareRecipient(ctx, r)
+		if err != nil {
+			return nil, fmt.Errorf("recipient %d: %w", i, err)
+		}
+		wallets[i] = w
+	}
+
+	quote := strings.TrimSpace(in.QuoteMint)
+	if quote == "" {
+		quote = WrappedSOLMint
+	}
+	req := &CreateFeeShareConfigRequest{
+		WalletA:    wallets[0],
+		WalletB:    wallets[0],
+		WalletABps: in.Recipients[0].Bps,
+		Payer:      c.signerWallet(in.Payer),
+		BaseMint:   in.BaseMint,
+		QuoteMint:  quote,
+	}
+	if len(wallets) == 2 {
+		req.WalletB, req.WalletBBps = wallets[1], in.Recipients[1].Bps
+	}
+	return req, nil
+}
+
+// resolveFeeShareRecipient returns the recipient's wallet, looking up the fee
+// share wallet when a Twitter username is given.
+func (c *BagsClient) resolveFeeShareRecipient(ctx context.Context, r FeeShareRecipient) (string, error) {
+	wallet := strings.TrimSpace(r.Wallet)
+	handle := strings.TrimSpace(r.TwitterUsername)
+	if strings.HasPrefix(wallet, "@") {
+		if handle != "" {
+			return "", fmt.Errorf("only one of wallet or twitterUsername may be set")
+		}
+		wallet, handle = "", wallet
+	}
+	switch {
+	case wallet != "" && handle != "":
+		return "", fmt.Errorf("only one of wallet or twitterUsername may be set")
+	case wallet != "":
+		return wallet, nil
+	case handle != "":
+		return c.feeShareWallet(ctx, strings.TrimPrefix(handle, "@"))
+	default:
+		return "", fmt.Errorf("wallet or twitterUsername is required")
+	}
+}
+
+// validateFeeShareRecipients checks that each allocation is in range, the
+// total equals TotalBps, and the endpoint supports the recipient count.
+func validateFeeShareRecipients(rs []FeeShareRecipient) error {
+	if len(rs) == 0 {
+		return fmt.Errorf("at least one recipient is required")
+	}
+	if len(rs) > MaxFeeShareRecipients {
+		return &RecipientCountError{Count: len(rs)}
+	}
+	var total int64
+	for i, r := range rs {
+		if r.Bps < 0 || r.Bps > TotalBps {
+			return fmt.Errorf("recipient %d: bps must be between 0 and %d, got %d", i, TotalBps, r.Bps)
+		}
+		total += r.Bps
+	}
+	if total != TotalBps {
+		return fmt.Errorf("recipient bps must sum to %d, got %d", TotalBps, total)
+	}
+	return nil
+}
+
+// -------------------- Fee Share Config Builder --------------------
+
+// FeeShareConfigBuilder assembles a FeeShareRecipientsRequest.
+//
+//	res, err := bags.NewFeeShareConfigBuilder(tokenMint, payer).
+//		Wallet(payer, 1000).
+//		Twitter("alice123", 9000).
+//		Create(ctx, client)
+type FeeShareConfigBuilder struct {
+	req FeeShareRecipientsRequest
+}
+
+// NewFeeShareConfigBuilder starts a fee share config for baseMint paid by payer.
+func NewFeeShareConfigBuilder(baseMint, payer string) *FeeShareConfigBuilder {
+	return &FeeShareConfigBuilder{req: FeeShareRecipientsRequest{
+		BaseMint: baseMint,
+		Payer:    payer,
+	}}
+}
+
+// Wallet adds a recipient identified by wallet address.
+func (b *FeeShareConfigBuilder) Wallet(wallet string, bps int64) *FeeShareConfigBuilder {
+	b.req.Recipients = append(b.req.Recipients, FeeShareRecipient{Wallet: wallet, Bps: bps})
+	return b
+}
+
+// Twitter adds a recipient identified by Twitter username.
+func (b *FeeShareConfigBuilder) Twitter(username string, bps int64) *FeeShareConfigBuilder {
+	b.req.Recipients = append(b.req.Recipients, FeeShareRecipient{TwitterUsername: username, Bps: bps})
+	return b
+}
+
+// QuoteMint overrides the quote mint (defaults to WrappedSOLMint).
+func (b *FeeShareConfigBuilder) QuoteMint(mint string) *FeeShareConfigBuilder {
+	b.req.QuoteMint = mint
+	return b
+}
+
+// Request returns a copy of the assembled request.
+func (b *FeeShareConfigBuilder) Request() *FeeShareRecipientsRequest {
+	req := b.req
+	req.Recipients = append([]FeeShareRecipient(nil), b.req.Recipients...)
+	return &req
+}
+
+// Validate checks the recipient allocation without making any network calls,
+// including the recipient count; see RecipientCountError.
+func (b *FeeShareConfigBuilder) Validate() error {
+	return validateFeeShareRecipients(b.req.Recipients)
+}
+
+// Create submits the assembled config through c.CreateFeeShareConfigFromRecipients.
+func (b *FeeShareConfigBuilder) Create(ctx context.Context, c *BagsClient) (*CreateFeeShareConfigResult, error) {
+	return c.CreateFeeShareConfigFromRecipients(ctx, b.Request())
+}
@@ -0,0 +1,95 @@
+// feeshare_recipients_test.go
+package bags_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+const (
+	testMint   = "6fX3mEVGdVBVGLfTiTBtp2Vh5aV7LkR6t4G1mA7bBAGS"
+	testWallet = "9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin"
+	testOther  = "4Nd1mBQtrMJVYVfKf2PJy9NZUZdTAsp7D4xWLs4gDB4T"
+)
+
+func TestFeeShareConfigBuilderValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*bags.FeeShareConfigBuilder)
+		ok    bool
+		count bool // fails with ErrUnsupportedRecipients
+	}{
+		{"no recipients", func(b *bags.FeeShareConfigBuilder) {}, false, false},
+		{"single recipient", func(b *bags.FeeShareConfigBuilder) { b.Wallet(testWallet, bags.TotalBps) }, true, false},
+		{"pair", func(b *bags.FeeShareConfigBuilder) { b.Wallet(testWallet, 1000).Twitter("alice", 9000) }, true, false},
+		{"short of total", func(b *bags.FeeShareConfigBuilder) { b.Wallet(testWallet, 9999) }, false, false},
+		{"negative bps", func(b *bags.FeeShareConfigBuilder) { b.Wallet(testWallet, -1).Wallet(testOther, 10001) }, false, false},
+		{"three recipients", func(b *bags.FeeShareConfigBuilder) {
+			b.Wallet(testWallet, 5000).Wallet(testOther, 2500).Twitter("alice", 2500)
+		}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := bags.NewFeeShareConfigBuilder(testMint, testWallet)
+			tt.build(b)
+			err := b.Validate()
+			if (err == nil) != tt.ok {
+				t.Fatalf("Validate() = %v, want ok = %v", err, tt.ok)
+			}
+			if got := errors.Is(err, bags.ErrUnsupportedRecipients); got != tt.count {
+				t.Errorf("errors.Is(err, ErrUnsupportedRecipients) = %v, want %v", got, tt.count)
+			}
+		})
+	}
+}
+
+func TestCreateFeeShareConfigFromRecipientsWire(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*bags.FeeShareConfigBuilder)
+		want  bags.CreateFeeShareConfigRequest
+	}{
+		{
+			"single recipient",
+			func(b *bags.FeeShareConfigBuilder) { b.Wallet(testWallet, bags.TotalBps) },
+			bags.CreateFeeShareConfigRequest{WalletA: testWallet, WalletB: testWallet, WalletABps: bags.TotalBps},
+		},
+		{
+			"pair",
+			func(b *bags.FeeShareConfigBuilder) { b.Wallet(testWallet, 2500).Wallet(testOther, 7500) },
+			bags.CreateFeeShareConfigRequest{WalletA: testWallet, WalletB: testOther, WalletABps: 2500, WalletBBps: 7500},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bags.CreateFeeShareConfigRequest
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				_, _ = w.Write([]byte(`{"success":true,"response":{"tx":"","configKey":"cfg"}}`))
+			}))
+			defer srv.Close()
+			c, err := bags.New("test-key", srv.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.BaseURL = srv.URL + "/api/v1/"
+
+			b := bags.NewFeeShareConfigBuilder(testMint, testWallet)
+			tt.build(b)
+			if _, err := b.Create(context.Background(), c); err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			want.Payer, want.BaseMint, want.QuoteMint = testWallet, testMint, bags.WrappedSOLMint
+			if got != want {
+				t.Errorf("sent %+v, want %+v", got, want)
+			}
+		})
+	}
+}
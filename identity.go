@@ -0,0 +1,317 @@
+// identity.go
+package bags
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultIdentityTTL is how long IdentityService trusts a resolved identity
+// when its TTL is zero.
+const DefaultIdentityTTL = 10 * time.Minute
+
+// -------------------- Identities --------------------
+
+// Identity links a wallet to the Bags user and Twitter account behind it, as
+// far as they are known. Fields not learned yet are empty.
+type Identity struct {
+	Wallet          string    `json:"wallet"`
+	TwitterUsername string    `json:"twitterUsername,omitempty"`
+	Username        string    `json:"username,omitempty"`
+	Pfp             string    `json:"pfp,omitempty"`
+	ResolvedAt      time.Time `json:"resolvedAt"`
+}
+
+// IdentityService caches wallet, Twitter, and username mappings learned
+// from the fee share wallet and launch creators endpoints, so repeated
+// identity lookups cost no requests. Fee share wallets are kept apart from
+// the wallets creators launched from, which may differ for the same Twitter
+// account. Entries expire after TTL and can be dropped early with
+// Invalidate. It is safe for concurrent use.
+//
+// Set it as BagsClient.Identities to have fee share recipients and Twitter
+// claim lookups resolve through the cache too.
+type IdentityService struct {
+	Client *BagsClient
+	// TTL is how long an identity is trusted. Zero means DefaultIdentityTTL.
+	TTL time.Duration
+	// OnInvalidate, when set, is called with identities dropped by
+	// Invalidate, by InvalidateAll, or because a lookup showed a Twitter
+	// account moved to another wallet. It must not call back into the
+	// service.
+	OnInvalidate func(Identity)
+
+	mu         sync.Mutex
+	byWallet   map[string]*Identity
+	byTwitter  map[string]string        // normalized handle -> wallet
+	byUsername map[string]string        // normalized username -> wallet
+	feeShare   map[string]feeShareEntry // normalized handle -> fee share wallet
+}
+
+type feeShareEntry struct {
+	wallet, twitter string
+	at              time.Time
+}
+
+// NewIdentityService returns an empty identity cache over c.
+func NewIdentityService(c *BagsClient, ttl time.Duration) *IdentityService {
+	return &IdentityService{Client: c, TTL: ttl}
+}
+
+// ByTwitter returns the identity of a Twitter user's fee share wallet,
+// resolving it when it is not cached. Wallets learned from launch creators
+// are never returned here.
+func (s *IdentityService) ByTwitter(ctx context.Context, twitterUsername string) (Identity, error) {
+	handle := normalizeHandle(twitterUsername)
+	s.mu.Lock()
+	id, ok := s.feeShareLocked(handle)
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+	name := strings.TrimPrefix(strings.TrimSpace(twitterUsername), "@")
+	wallet, err := s.Client.GetFeeShareWallet(ctx, name)
+	if err != nil {
+		return Identity{}, err
+	}
+	return s.learnFeeShare(handle, feeShareEntry{wallet: wallet, twitter: name, at: time.Now()}), nil
+}
+
+// WalletForTwitter returns the fee share wallet of a Twitter user.
+func (s *IdentityService) WalletForTwitter(ctx context.Context, twitterUsername string) (string, error) {
+	id, err := s.ByTwitter(ctx, twitterUsername)
+	return id.Wallet, err
+}
+
+// ByWallet returns the cached identity of wallet. The API cannot look up a
+// wallet's owner directly; identities are learned through ByTwitter,
+// CreatorsOf, and Observe.
+func (s *IdentityService) ByWallet(wallet string) (Identity, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lookupLocked(nil, strings.TrimSpace(wallet))
+}
+
+// ByUsername returns the cached identity of a Bags username.
+func (s *IdentityService) ByUsername(username string) (Identity, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lookupLocked(s.byUsername, normalizeHandle(username))
+}
+
+// CreatorsOf returns the launch creators of mint and caches their
+// identities.
+func (s *IdentityService) CreatorsOf(ctx context.Context, mint string) ([]TokenCreator, error) {
+	creators, err := s.Client.GetTokenLaunchCreators(ctx, mint)
+	if err != nil {
+		return nil, err
+	}
+	s.Observe(creators...)
+	return creators, nil
+}
+
+// Observe caches the identities of creators fetched elsewhere, e.g. by
+// GetTokenLaunchCreatorsBatch.
+func (s *IdentityService) Observe(creators ...TokenCreator) {
+	for _, cr := range creators {
+		if strings.TrimSpace(cr.Wallet) == "" {
+			continue
+		}
+		s.learn(Identity{Wallet: cr.Wallet, TwitterUsername: cr.TwitterUsername, Username: cr.Username, Pfp: cr.Pfp})
+	}
+}
+
+// Invalidate drops the identity matching key, which may be a wallet, a
+// Twitter username, or a Bags username, along with the fee share wallet
+// cached for that wallet or Twitter username. It reports whether one was
+// cached.
+func (s *IdentityService) Invalidate(key string) bool {
+	s.mu.Lock()
+	wallet, handle := strings.TrimSpace(key), normalizeHandle(key)
+	if _, ok := s.byWallet[wallet]; !ok {
+		if w, ok := s.byTwitter[handle]; ok {
+			wallet = w
+		} else if w, ok := s.byUsername[handle]; ok {
+			wallet = w
+		} else {
+			wallet = s.feeShare[handle].wallet
+		}
+	}
+	_, dropped := s.feeShare[handle]
+	delete(s.feeShare, handle)
+	for h, e := range s.feeShare {
+		if e.wallet == wallet {
+			delete(s.feeShare, h)
+			dropped = true
+		}
+	}
+	id, ok := s.removeLocked(wallet)
+	s.mu.Unlock()
+	if ok {
+		s.notify(id)
+	}
+	return ok || dropped
+}
+
+// InvalidateAll empties the cache.
+func (s *IdentityService) InvalidateAll() {
+	s.mu.Lock()
+	dropped := make([]Identity, 0, len(s.byWallet))
+	for _, id := range s.byWallet {
+		dropped = append(dropped, *id)
+	}
+	s.byWallet, s.byTwitter, s.byUsername, s.feeShare = nil, nil, nil, nil
+	s.mu.Unlock()
+	for _, id := range dropped {
+		s.notify(id)
+	}
+}
+
+// ------- Internal Helpers -------
+
+func normalizeHandle(h string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(h), "@"))
+}
+
+func (s *IdentityService) ttl() time.Duration {
+	if s.TTL <= 0 {
+		return DefaultIdentityTTL
+	}
+	return s.TTL
+}
+
+// lookupLocked resolves key through index (or as a wallet when index is
+// nil) and returns the identity if it has not expired.
+func (s *IdentityService) lookupLocked(index map[string]string, key string) (Identity, bool) {
+	wallet := key
+	if index != nil {
+		wallet = index[key]
+	}
+	id, ok := s.byWallet[wallet]
+	if !ok || time.Since(id.ResolvedAt) >= s.ttl() {
+		return Identity{}, false
+	}
+	return *id, true
+}
+
+// feeShareLocked returns the cached fee share wallet of handle, with the
+// rest of its identity when the wallet is known under the same handle.
+func (s *IdentityService) feeShareLocked(handle string) (Identity, bool) {
+	e, ok := s.feeShare[handle]
+	if !ok || time.Since(e.at) >= s.ttl() {
+		return Identity{}, false
+	}
+	if cur, ok := s.byWallet[e.wallet]; ok && normalizeHandle(cur.TwitterUsername) == handle {
+		return *cur, true
+	}
+	return Identity{Wallet: e.wallet, TwitterUsername: e.twitter, ResolvedAt: e.at}, true
+}
+
+// learnFeeShare records a fee share wallet lookup. The wallet is added to
+// the identity cache when unknown, but stays out of the Twitter index so it
+// never displaces an identity learned from launch creators.
+func (s *IdentityService) learnFeeShare(handle string, e feeShareEntry) Identity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initLocked()
+	s.feeShare[handle] = e
+	if _, ok := s.byWallet[e.wallet]; !ok {
+		s.byWallet[e.wallet] = &Identity{Wallet: e.wallet, TwitterUsername: e.twitter, ResolvedAt: e.at}
+	}
+	id, _ := s.feeShareLocked(handle)
+	return id
+}
+
+func (s *IdentityService) initLocked() {
+	if s.byWallet == nil {
+		s.byWallet = make(map[string]*Identity)
+		s.byTwitter = make(map[string]string)
+		s.byUsername = make(map[string]string)
+		s.feeShare = make(map[string]feeShareEntry)
+	}
+}
+
+// learn merges a creator identity into the cache and returns the merged
+// identity. A Twitter account or username seen on a new wallet is moved
+// there, and the identity it left is reported as invalidated; the entries
+// of a handle or username the wallet no longer has are dropped.
+func (s *IdentityService) learn(id Identity) Identity {
+	id.ResolvedAt = time.Now()
+	var moved []Identity
+	s.mu.Lock()
+	s.initLocked()
+	cur, ok := s.byWallet[id.Wallet]
+	if !ok {
+		cur = &Identity{Wallet: id.Wallet}
+		s.byWallet[id.Wallet] = cur
+	}
+	if id.TwitterUsername != "" {
+		h := normalizeHandle(id.TwitterUsername)
+		if prev := normalizeHandle(cur.TwitterUsername); prev != h && s.byTwitter[prev] == id.Wallet {
+			delete(s.byTwitter, prev)
+		}
+		if old, ok := s.byTwitter[h]; ok && old != id.Wallet {
+			if prev, ok := s.removeLocked(old); ok {
+				moved = append(moved, prev)
+			}
+		}
+		s.byTwitter[h] = id.Wallet
+		cur.TwitterUsername = id.TwitterUsername
+	}
+	if id.Username != "" {
+		u := normalizeHandle(id.Username)
+		if prev := normalizeHandle(cur.Username); prev != u && s.byUsername[prev] == id.Wallet {
+			delete(s.byUsername, prev)
+		}
+		if old, ok := s.byUsername[u]; ok && old != id.Wallet {
+			if prev, ok := s.removeLocked(old); ok {
+				moved = append(moved, prev)
+			}
+		}
+		s.byUsername[u] = id.Wallet
+		cur.Username = id.Username
+	}
+	if id.Pfp != "" {
+		cur.Pfp = id.Pfp
+	}
+	cur.ResolvedAt = id.ResolvedAt
+	out := *cur
+	s.mu.Unlock()
+	for _, m := range moved {
+		s.notify(m)
+	}
+	return out
+}
+
+// removeLocked drops wallet's identity and its index entries.
+func (s *IdentityService) removeLocked(wallet string) (Identity, bool) {
+	id, ok := s.byWallet[wallet]
+	if !ok {
+		return Identity{}, false
+	}
+	delete(s.byWallet, wallet)
+	if h := normalizeHandle(id.TwitterUsername); s.byTwitter[h] == wallet {
+		delete(s.byTwitter, h)
+	}
+	if u := normalizeHandle(id.Username); s.byUsername[u] == wallet {
+		delete(s.byUsername, u)
+	}
+	return *id, true
+}
+
+func (s *IdentityService) notify(id Identity) {
+	if s.OnInvalidate != nil {
+		s.OnInvalidate(id)
+	}
+}
+
+// feeShareWallet resolves a Twitter user's fee share wallet through
+// Identities when set.
+func (c *BagsClient) feeShareWallet(ctx context.Context, twitterUsername string) (string, error) {
+	if c.Identities != nil {
+		return c.Identities.WalletForTwitter(ctx, twitterUsername)
+	}
+	return c.GetFeeShareWallet(ctx, twitterUsername)
+}
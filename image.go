@@ -0,0 +1,180 @@
+// image.go
+package bags
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF for dimension checks
+	_ "image/jpeg" // register JPEG for dimension checks
+	_ "image/png"  // register PNG for dimension checks
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// DefaultMaxImageBytes caps image downloads when ImageFetchOptions.MaxBytes is zero.
+const DefaultMaxImageBytes int64 = 10 << 20
+
+// -------------------- Image Fetching --------------------
+
+// ImageFetchOptions controls verification applied by FetchImage.
+type ImageFetchOptions struct {
+	// MaxBytes caps the decoded image size. Zero means DefaultMaxImageBytes.
+	MaxBytes int64
+	// MaxWidth and MaxHeight reject images whose decoded dimensions exceed
+	// them. Zero means unlimited. When either is set the image must be in a
+	// format whose header can be decoded (PNG, JPEG, GIF).
+	MaxWidth  int
+	MaxHeight int
+	// SHA256 is an optional hex-encoded digest that the image bytes must match.
+	SHA256 string
+}
+
+// FetchedImage is an image downloaded and verified by FetchImage.
+type FetchedImage struct {
+	Data     []byte
+	Filename string
+	MIMEType string
+	Width    int // zero when the format could not be decoded
+	Height   int // zero when the format could not be decoded
+	SHA256   string
+}
+
+// Apply sets the image fields of a CreateTokenInfoRequest from the fetched image.
+func (img *FetchedImage) Apply(in *CreateTokenInfoRequest) {
+	in.Image = bytes.NewReader(img.Data)
+	in.ImageFilename = img.Filename
+	in.ImageMIMEType = img.MIMEType
+}
+
+// FetchImage downloads an image for upload and verifies it before it is used
+// in a launch.
+//
+// Compressed responses are transparently decoded. The transferred byte count
+// is checked against Content-Length so truncated downloads are rejected, the
+// decoded dimensions are checked against MaxWidth/MaxHeight, and the content
+// is compared against SHA256 when provided. The API key is never sent.
+func (c *BagsClient) FetchImage(ctx context.Context, imageURL string, opts *ImageFetchOptions) (*FetchedImage, error) {
+	if strings.TrimSpace(imageURL) == "" {
+		return nil, fmt.Errorf("image URL is required")
+	}
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse image URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported image URL scheme %q", u.Scheme)
+	}
+	var o ImageFetchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = DefaultMaxImageBytes
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if ua := strings.TrimSpace(c.Config().UserAgent); ua != "" {
+		req.Header.Set("User-Agent", ua)
+	}
+	res, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch image: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("fetch image: %s", res.Status)
+	}
+
+	// When the transport already decompressed the body, Content-Length no
+	// longer describes what we read, so only check it for raw bodies.
+	raw := &countingReader{r: res.Body}
+	var body io.Reader = raw
+	switch strings.ToLower(strings.TrimSpace(res.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decompress image: %w", err)
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil, fmt.Errorf("unsupported image content encoding %q", res.Header.Get("Content-Encoding"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, o.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	if int64(len(data)) > o.MaxBytes {
+		return nil, fmt.Errorf("image exceeds %d bytes", o.MaxBytes)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("image is empty")
+	}
+	if !res.Uncompressed && res.ContentLength >= 0 && raw.n != res.ContentLength {
+		return nil, fmt.Errorf("image truncated: read %d of %d bytes", raw.n, res.ContentLength)
+	}
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	if want := strings.TrimSpace(o.SHA256); want != "" && !strings.EqualFold(want, digest) {
+		return nil, fmt.Errorf("image checksum mismatch: got %s, want %s", digest, want)
+	}
+
+	img := &FetchedImage{
+		Data:     data,
+		Filename: imageFilename(u),
+		MIMEType: imageMIMEType(res.Header.Get("Content-Type"), data),
+		SHA256:   digest,
+	}
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+		img.Width, img.Height = cfg.Width, cfg.Height
+	} else if o.MaxWidth > 0 || o.MaxHeight > 0 {
+		return nil, fmt.Errorf("decode image dimensions: %w", err)
+	}
+	if o.MaxWidth > 0 && img.Width > o.MaxWidth || o.MaxHeight > 0 && img.Height > o.MaxHeight {
+		return nil, fmt.Errorf("image is %dx%d, exceeds %dx%d", img.Width, img.Height, o.MaxWidth, o.MaxHeight)
+	}
+	return img, nil
+}
+
+// ------- Internal Helpers -------
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func imageFilename(u *url.URL) string {
+	name := path.Base(u.Path)
+	if name == "" || name == "." || name == "/" {
+		return "image"
+	}
+	return name
+}
+
+func imageMIMEType(header string, data []byte) string {
+	if mt, _, err := mime.ParseMediaType(header); err == nil && strings.HasPrefix(mt, "image/") {
+		return mt
+	}
+	return http.DetectContentType(data)
+}
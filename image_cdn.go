@@ -0,0 +1,181 @@
+// image_cdn.go
+package bags
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImageVariant selects a rendition of a launch image.
+type ImageVariant int
+
+const (
+	// ImageFull is the original image.
+	ImageFull ImageVariant = iota
+	// ImageThumbnail is a resized image, ImageCDN.ThumbnailWidth pixels wide.
+	ImageThumbnail
+)
+
+// -------------------- Launch Image URLs --------------------
+
+// ImageCDN maps stored launch image references to fetchable URLs.
+//
+// Stored images have been returned as ipfs:// URIs, bare CIDs, /ipfs/ paths,
+// and URLs on assorted public gateways. ImageCDN rewrites all IPFS forms onto
+// a single gateway so downstream renderers see one stable format.
+type ImageCDN struct {
+	// IPFSGateway is the gateway prefix, e.g. "https://ipfs.io/ipfs/".
+	IPFSGateway string
+	// WidthParam is the query parameter the gateway uses to resize images
+	// (for example "img-width" on Pinata). Empty disables resizing, in which
+	// case ImageThumbnail returns the full image.
+	WidthParam string
+	// ThumbnailWidth is the width requested for ImageThumbnail.
+	ThumbnailWidth int
+	// CacheBustParam is the query parameter carrying the image version.
+	// Empty disables cache busting.
+	CacheBustParam string
+}
+
+// DefaultImageCDN serves images from the public ipfs.io gateway.
+var DefaultImageCDN = ImageCDN{
+	IPFSGateway:    "https://ipfs.io/ipfs/",
+	ThumbnailWidth: 256,
+	CacheBustParam: "v",
+}
+
+// ImageURL returns the launch image URL for variant using DefaultImageCDN,
+// versioned by the launch's updatedAt timestamp.
+func (t TokenLaunchObj) ImageURL(variant ImageVariant) (string, error) {
+	return DefaultImageCDN.URL(t.Image, variant, imageVersion(t.UpdatedAtISO))
+}
+
+// NormalizeImageURL rewrites a stored image reference to a canonical https
+// URL using DefaultImageCDN.
+func NormalizeImageURL(raw string) (string, error) {
+	return DefaultImageCDN.Normalize(raw)
+}
+
+// Normalize rewrites a stored image reference to a canonical https URL.
+func (cdn ImageCDN) Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", fmt.Errorf("empty image URL")
+	}
+	if p, ok := ipfsPath(raw); ok {
+		gw := cdn.IPFSGateway
+		if gw == "" {
+			gw = DefaultImageCDN.IPFSGateway
+		}
+		return strings.TrimRight(gw, "/") + "/" + p, nil
+	}
+	if strings.HasPrefix(raw, "//") {
+		raw = "https:" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("parse image URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+	case "http":
+		u.Scheme = "https"
+	default:
+		return "", fmt.Errorf("unsupported image URL %q", raw)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("unsupported image URL %q", raw)
+	}
+	return u.String(), nil
+}
+
+// URL normalizes raw and applies the variant and cache-busting version.
+// An empty version skips cache busting.
+func (cdn ImageCDN) URL(raw string, variant ImageVariant, version string) (string, error) {
+	norm, err := cdn.Normalize(raw)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(norm)
+	if err != nil {
+		return "", fmt.Errorf("parse image URL: %w", err)
+	}
+	q := u.Query()
+	switch variant {
+	case ImageFull:
+	case ImageThumbnail:
+		if cdn.WidthParam != "" && cdn.ThumbnailWidth > 0 {
+			q.Set(cdn.WidthParam, strconv.Itoa(cdn.ThumbnailWidth))
+		}
+	default:
+		return "", fmt.Errorf("unknown image variant %d", variant)
+	}
+	if cdn.CacheBustParam != "" && version != "" {
+		q.Set(cdn.CacheBustParam, version)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// ------- Internal Helpers -------
+
+// ipfsPath extracts "<cid>[/path]" from any IPFS reference form.
+func ipfsPath(raw string) (string, bool) {
+	switch {
+	case strings.HasPrefix(raw, "ipfs://"):
+		p := strings.TrimPrefix(strings.TrimPrefix(raw, "ipfs://"), "ipfs/")
+		return p, p != ""
+	case strings.HasPrefix(raw, "/ipfs/"):
+		p := strings.TrimPrefix(raw, "/ipfs/")
+		return p, p != ""
+	case isCID(raw):
+		return raw, true
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	// Path gateways: https://<gateway>/ipfs/<cid>/...
+	if i := strings.Index(u.Path, "/ipfs/"); i >= 0 {
+		p := u.Path[i+len("/ipfs/"):]
+		if cid, _, _ := strings.Cut(p, "/"); isCID(cid) {
+			return p, true
+		}
+	}
+	// Subdomain gateways: https://<cid>.ipfs.<gateway>/...
+	if label, rest, ok := strings.Cut(u.Host, ".ipfs."); ok && rest != "" && isCID(label) {
+		return label + u.Path, true
+	}
+	return "", false
+}
+
+// isCID reports whether s looks like a CIDv0 (Qm…, 46 chars) or CIDv1 in base32 (b…).
+func isCID(s string) bool {
+	switch {
+	case len(s) == 46 && strings.HasPrefix(s, "Qm"):
+		_, err := Base58Decode(s)
+		return err == nil
+	case len(s) > 50 && strings.HasPrefix(s, "b"):
+		for _, r := range s[1:] {
+			if (r < 'a' || r > 'z') && (r < '2' || r > '7') {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// imageVersion turns an ISO timestamp into a compact cache-busting token.
+func imageVersion(iso string) string {
+	if iso == "" {
+		return ""
+	}
+	if t, err := time.Parse(time.RFC3339, iso); err == nil {
+		return strconv.FormatInt(t.Unix(), 10)
+	}
+	return iso
+}
@@ -0,0 +1,172 @@
+// image_upload.go
+package bags
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultPreUploadThreshold is the image size above which images are
+// pre-uploaded when BagsClient.PreUploadThreshold is zero.
+const DefaultPreUploadThreshold int64 = 4 << 20
+
+// -------------------- Image Pre-Upload --------------------
+
+// ImageUpload is an image handed to an ImageUploader.
+type ImageUpload struct {
+	Body     io.Reader
+	Size     int64
+	Filename string
+	MIMEType string
+}
+
+// ImageUploader stores an image out of band and returns a URL that
+// create-token-info can reference instead of an inline file.
+type ImageUploader interface {
+	UploadImage(ctx context.Context, img *ImageUpload) (string, error)
+}
+
+// ImageUploaderFunc adapts a function to ImageUploader.
+type ImageUploaderFunc func(ctx context.Context, img *ImageUpload) (string, error)
+
+func (f ImageUploaderFunc) UploadImage(ctx context.Context, img *ImageUpload) (string, error) {
+	return f(ctx, img)
+}
+
+// SignedUpload is a pre-signed upload target.
+type SignedUpload struct {
+	// URL receives the image bytes.
+	URL string
+	// Method defaults to PUT.
+	Method string
+	// Headers are sent with the upload, e.g. signed Content-Type or ACL headers.
+	Headers http.Header
+	// ImageURL is the public URL of the uploaded image. When empty, URL
+	// without its query string is used.
+	ImageURL string
+}
+
+// SignedURLUploader uploads images in two steps: Presign obtains a signed
+// upload URL (from the Bags API or the caller's own storage), then the image
+// is sent to it directly. The API key is never sent to the upload URL.
+type SignedURLUploader struct {
+	Presign func(ctx context.Context, img *ImageUpload) (*SignedUpload, error)
+	// HTTP defaults to http.DefaultClient.
+	HTTP *http.Client
+}
+
+// UploadImage implements ImageUploader.
+func (u *SignedURLUploader) UploadImage(ctx context.Context, img *ImageUpload) (string, error) {
+	if u.Presign == nil {
+		return "", fmt.Errorf("presign func is required")
+	}
+	su, err := u.Presign(ctx, img)
+	if err != nil {
+		return "", fmt.Errorf("presign image upload: %w", err)
+	}
+	if su == nil || strings.TrimSpace(su.URL) == "" {
+		return "", fmt.Errorf("presign image upload: empty upload URL")
+	}
+	target, err := url.Parse(su.URL)
+	if err != nil {
+		return "", fmt.Errorf("parse upload URL: %w", err)
+	}
+	method := su.Method
+	if method == "" {
+		method = http.MethodPut
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), img.Body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = img.Size
+	for k, vs := range su.Headers {
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	if req.Header.Get("Content-Type") == "" && img.MIMEType != "" {
+		req.Header.Set("Content-Type", img.MIMEType)
+	}
+	hc := u.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("upload image: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return "", fmt.Errorf("upload image: %s", res.Status)
+	}
+
+	if su.ImageURL != "" {
+		return su.ImageURL, nil
+	}
+	target.RawQuery, target.Fragment = "", ""
+	return target.String(), nil
+}
+
+// ------- Internal Helpers -------
+
+// preUploadImage uploads in.Image through c.ImageUploader when it is larger
+// than the pre-upload threshold, returning a copy of in that references the
+// uploaded URL. Images of unknown size are always sent inline.
+func (c *BagsClient) preUploadImage(ctx context.Context, in *CreateTokenInfoRequest) (*CreateTokenInfoRequest, error) {
+	if c.ImageUploader == nil || in.Image == nil {
+		return in, nil
+	}
+	size, ok := readerSize(in.Image)
+	threshold := c.PreUploadThreshold
+	if threshold <= 0 {
+		threshold = DefaultPreUploadThreshold
+	}
+	if !ok || size <= threshold {
+		return in, nil
+	}
+	mt := in.ImageMIMEType
+	if strings.TrimSpace(mt) == "" {
+		mt = "application/octet-stream"
+	}
+	imageURL, err := c.ImageUploader.UploadImage(ctx, &ImageUpload{
+		Body:     in.Image,
+		Size:     size,
+		Filename: in.ImageFilename,
+		MIMEType: mt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := *in
+	out.Image = nil
+	out.ImageURL = imageURL
+	return &out, nil
+}
+
+// readerSize reports the remaining length of common sized readers.
+func readerSize(r io.Reader) (int64, bool) {
+	switch v := r.(type) {
+	case interface{ Len() int }: // *bytes.Reader, *bytes.Buffer, *strings.Reader
+		return int64(v.Len()), true
+	case interface{ Stat() (fs.FileInfo, error) }: // *os.File
+		fi, err := v.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return 0, false
+		}
+		if s, ok := r.(io.Seeker); ok {
+			if off, err := s.Seek(0, io.SeekCurrent); err == nil {
+				return fi.Size() - off, true
+			}
+		}
+		return fi.Size(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,53 @@
+// keypair.go
+package bags
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ParseKeypair decodes a Solana keypair in either the solana-keygen JSON
+// format (an array of 64 byte values) or as a base58-encoded 64-byte secret key.
+func ParseKeypair(data []byte) (ed25519.PrivateKey, error) {
+	s := strings.TrimSpace(string(data))
+	var raw []byte
+	if strings.HasPrefix(s, "[") {
+		var ints []int
+		if err := json.Unmarshal([]byte(s), &ints); err != nil {
+			return nil, fmt.Errorf("parse keypair: %w", err)
+		}
+		raw = make([]byte, len(ints))
+		for i, v := range ints {
+			if v < 0 || v > 255 {
+				return nil, fmt.Errorf("parse keypair: byte %d out of range", i)
+			}
+			raw[i] = byte(v)
+		}
+	} else {
+		var err error
+		if raw, err = Base58Decode(s); err != nil {
+			return nil, fmt.Errorf("parse keypair: %w", err)
+		}
+	}
+	if len(raw) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("parse keypair: got %d bytes, want %d", len(raw), ed25519.PrivateKeySize)
+	}
+	key := ed25519.PrivateKey(raw)
+	// The last 32 bytes must be the public key derived from the seed.
+	if !key.Public().(ed25519.PublicKey).Equal(ed25519.PublicKey(raw[32:])) {
+		return nil, fmt.Errorf("parse keypair: public key does not match secret key")
+	}
+	return key, nil
+}
+
+// ReadKeypairFile loads a keypair file written by solana-keygen.
+func ReadKeypairFile(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeypair(data)
+}
@@ -0,0 +1,100 @@
+// lamports.go
+package bags
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// LamportsPerSOL is the number of lamports in one SOL.
+const LamportsPerSOL = 1_000_000_000
+
+// Lamports is an amount of SOL expressed in lamports.
+//
+// It marshals to a JSON number and unmarshals from either a JSON number or a
+// decimal string, which is how the API reports amounts such as lifetime fees.
+type Lamports uint64
+
+// LamportsFromSOL converts a SOL amount to lamports, rounding to the nearest
+// lamport. Negative and non-finite values yield an error.
+func LamportsFromSOL(sol float64) (Lamports, error) {
+	if math.IsNaN(sol) || math.IsInf(sol, 0) || sol < 0 {
+		return 0, fmt.Errorf("invalid SOL amount %v", sol)
+	}
+	v := math.Round(sol * LamportsPerSOL)
+	if v >= math.MaxUint64 {
+		return 0, fmt.Errorf("SOL amount %v overflows lamports", sol)
+	}
+	return Lamports(v), nil
+}
+
+// ParseLamports parses a base-10 lamport amount. Values that do not fit in
+// 64 bits are rejected rather than silently truncated.
+func ParseLamports(s string) (Lamports, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty lamports amount")
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return 0, fmt.Errorf("invalid lamports amount %q", s)
+	}
+	if n.Sign() < 0 {
+		return 0, fmt.Errorf("negative lamports amount %q", s)
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("lamports amount %q overflows uint64", s)
+	}
+	return Lamports(n.Uint64()), nil
+}
+
+// SOL returns the amount in SOL. Large amounts may lose precision; use
+// SOLString for exact output.
+func (l Lamports) SOL() float64 {
+	return float64(l) / LamportsPerSOL
+}
+
+// SOLString formats the amount in SOL exactly, without trailing zeros.
+func (l Lamports) SOLString() string {
+	whole, frac := uint64(l)/LamportsPerSOL, uint64(l)%LamportsPerSOL
+	if frac == 0 {
+		return strconv.FormatUint(whole, 10)
+	}
+	fs := strings.TrimRight(fmt.Sprintf("%09d", frac), "0")
+	return strconv.FormatUint(whole, 10) + "." + fs
+}
+
+// String returns the amount in lamports as a base-10 integer.
+func (l Lamports) String() string {
+	return strconv.FormatUint(uint64(l), 10)
+}
+
+// MarshalJSON encodes the amount as a JSON number.
+func (l Lamports) MarshalJSON() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number or a string holding a base-10 integer.
+func (l *Lamports) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseLamports(s)
+	if err != nil {
+		return err
+	}
+	*l = v
+	return nil
+}
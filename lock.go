@@ -0,0 +1,288 @@
+// lock.go
+package bags
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrLaunchConflict matches a LaunchConflictError with errors.Is.
+var ErrLaunchConflict = errors.New("launch conflict")
+
+// DefaultLaunchLockTTL bounds how long a launch lock is held when
+// LaunchGuard.TTL is zero.
+const DefaultLaunchLockTTL = 15 * time.Minute
+
+// -------------------- Locks --------------------
+
+// Lease describes who holds a lock and until when.
+type Lease struct {
+	Owner    string    `json:"owner"`
+	Acquired time.Time `json:"acquired"`
+	Expires  time.Time `json:"expires"`
+}
+
+// Locker coordinates exclusive work across processes, e.g. operators of one
+// team sharing a Redis, database, or file system. Expired leases must be
+// treated as free.
+type Locker interface {
+	// Acquire takes key for lease.Owner. While an unexpired lease is held,
+	// even by the same owner, it returns false and that lease.
+	Acquire(ctx context.Context, key string, lease Lease) (bool, Lease, error)
+	// Release frees key if it is held by owner.
+	Release(ctx context.Context, key, owner string) error
+}
+
+// MemoryLocker is a Locker for goroutines of a single process.
+type MemoryLocker struct {
+	mu     sync.Mutex
+	leases map[string]Lease
+}
+
+func (l *MemoryLocker) Acquire(_ context.Context, key string, lease Lease) (bool, Lease, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cur, ok := l.leases[key]; ok && time.Now().Before(cur.Expires) {
+		return false, cur, nil
+	}
+	if l.leases == nil {
+		l.leases = make(map[string]Lease)
+	}
+	l.leases[key] = lease
+	return true, lease, nil
+}
+
+func (l *MemoryLocker) Release(_ context.Context, key, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if cur, ok := l.leases[key]; ok && cur.Owner == owner {
+		delete(l.leases, key)
+	}
+	return nil
+}
+
+// FileLocker keeps one lock file per key in Dir, which may be a directory
+// shared between machines; the file system must support hard links. A lease
+// is written to a temporary file and linked onto the lock path, which fails
+// if the path exists, so only one writer can win. Expired lock files are
+// removed only while their contents are unchanged, and a lock file that
+// cannot be parsed is treated as held.
+type FileLocker struct {
+	Dir string
+}
+
+func (l *FileLocker) Acquire(_ context.Context, key string, lease Lease) (bool, Lease, error) {
+	if err := os.MkdirAll(l.Dir, 0o700); err != nil {
+		return false, Lease{}, err
+	}
+	data, err := json.Marshal(lease)
+	if err != nil {
+		return false, Lease{}, err
+	}
+	tmp, err := l.writeTemp(data)
+	if err != nil {
+		return false, Lease{}, err
+	}
+	defer os.Remove(tmp)
+
+	path := l.path(key)
+	for range 3 {
+		err := os.Link(tmp, path)
+		if err == nil {
+			// Confirm the link is ours before reporting success.
+			cur, raw, err := readLease(path)
+			if bytes.Equal(raw, data) {
+				return true, lease, nil
+			}
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return false, cur, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return false, Lease{}, err
+		}
+		cur, raw, err := readLease(path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			continue // released meanwhile
+		case errors.Is(err, errLeaseCorrupt):
+			return false, cur, nil // being written, or damaged: held
+		case err != nil:
+			return false, Lease{}, err
+		}
+		if time.Now().Before(cur.Expires) {
+			return false, cur, nil
+		}
+		// Expired: remove it unless it changed, then race to link again.
+		if err := removeIfUnchanged(path, raw); err != nil {
+			return false, Lease{}, err
+		}
+	}
+	return false, Lease{}, fmt.Errorf("lock %q: contended", key)
+}
+
+func (l *FileLocker) Release(_ context.Context, key, owner string) error {
+	path := l.path(key)
+	cur, raw, err := readLease(path)
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, errLeaseCorrupt) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cur.Owner != owner {
+		return nil
+	}
+	return removeIfUnchanged(path, raw)
+}
+
+func (l *FileLocker) path(key string) string {
+	return filepath.Join(l.Dir, url.PathEscape(key)+".lock")
+}
+
+func (l *FileLocker) writeTemp(data []byte) (string, error) {
+	f, err := os.CreateTemp(l.Dir, ".lease-*")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+var errLeaseCorrupt = errors.New("lease corrupt")
+
+// readLease returns the lease at path and its raw contents. A file that
+// does not parse, e.g. one still being written, fails with errLeaseCorrupt
+// and a Lease whose Acquired is the file's modification time.
+func readLease(path string) (Lease, []byte, error) {
+	var cur Lease
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cur, nil, err
+	}
+	if err := json.Unmarshal(data, &cur); err != nil {
+		if fi, err := os.Stat(path); err == nil {
+			cur.Acquired = fi.ModTime()
+		}
+		return cur, data, fmt.Errorf("read lock %s: %w", path, errLeaseCorrupt)
+	}
+	return cur, data, nil
+}
+
+// removeIfUnchanged removes path if it still holds raw, so a lease written
+// by another process since raw was read is never deleted.
+func removeIfUnchanged(path string, raw []byte) error {
+	now, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(now, raw) {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// -------------------- Launch Conflict Detection --------------------
+
+// LaunchGuard makes CreateTokenInfoAndMetadata take a lock on the token
+// symbol before uploading metadata, so two operators launching the same
+// symbol at the same time cannot both proceed.
+type LaunchGuard struct {
+	Locker Locker
+	// Owner identifies this operator in conflict errors. Empty means
+	// "<hostname>:<pid>:<random>", unique to this guard.
+	Owner string
+	// TTL bounds how long a launch holds its lock if it is never released.
+	// Zero means DefaultLaunchLockTTL.
+	TTL time.Duration
+
+	once sync.Once
+	id   string // default Owner
+}
+
+// LaunchConflictError reports that another operator is launching the same
+// symbol.
+type LaunchConflictError struct {
+	Symbol string
+	Holder Lease
+}
+
+func (e *LaunchConflictError) Error() string {
+	return fmt.Sprintf("launch conflict: %s is being launched by %s since %s (lock expires %s)",
+		e.Symbol, e.Holder.Owner, e.Holder.Acquired.Format(time.RFC3339), e.Holder.Expires.Format(time.RFC3339))
+}
+
+func (e *LaunchConflictError) Is(target error) bool { return target == ErrLaunchConflict }
+
+// ReleaseLaunch frees the launch lock for symbol taken by this client. It is
+// a no-op without a LaunchGuard.
+func (c *BagsClient) ReleaseLaunch(ctx context.Context, symbol string) error {
+	g := c.LaunchGuard
+	if g == nil || g.Locker == nil {
+		return nil
+	}
+	return g.Locker.Release(ctx, launchLockKey(symbol), g.owner())
+}
+
+// ------- Internal Helpers -------
+
+// guardLaunch takes the launch lock for symbol, or returns a
+// *LaunchConflictError when another operator holds it.
+func (c *BagsClient) guardLaunch(ctx context.Context, symbol string) error {
+	g := c.LaunchGuard
+	if g == nil || g.Locker == nil {
+		return nil
+	}
+	ttl := g.TTL
+	if ttl <= 0 {
+		ttl = DefaultLaunchLockTTL
+	}
+	now := time.Now()
+	ok, holder, err := g.Locker.Acquire(ctx, launchLockKey(symbol), Lease{Owner: g.owner(), Acquired: now, Expires: now.Add(ttl)})
+	if err != nil {
+		return fmt.Errorf("acquire launch lock: %w", err)
+	}
+	if !ok {
+		return &LaunchConflictError{Symbol: strings.ToUpper(strings.TrimSpace(symbol)), Holder: holder}
+	}
+	return nil
+}
+
+func (g *LaunchGuard) owner() string {
+	if g.Owner != "" {
+		return g.Owner
+	}
+	g.once.Do(func() {
+		host, _ := os.Hostname()
+		g.id = fmt.Sprintf("%s:%d:%s", host, os.Getpid(), rand.Text()[:8])
+	})
+	return g.id
+}
+
+func launchLockKey(symbol string) string {
+	return "launch/" + strings.ToUpper(strings.TrimSpace(symbol))
+}
@@ -0,0 +1,150 @@
+// lock_test.go
+package bags_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+func lease(owner string, ttl time.Duration) bags.Lease {
+	now := time.Now()
+	return bags.Lease{Owner: owner, Acquired: now, Expires: now.Add(ttl)}
+}
+
+func testLockers(t *testing.T) map[string]func() bags.Locker {
+	return map[string]func() bags.Locker{
+		"memory": func() bags.Locker { return &bags.MemoryLocker{} },
+		"file":   func() bags.Locker { return &bags.FileLocker{Dir: t.TempDir()} },
+	}
+}
+
+func TestLockerSequence(t *testing.T) {
+	ctx := context.Background()
+	type step struct {
+		op     string // "acquire" or "release"
+		owner  string
+		ttl    time.Duration
+		ok     bool   // acquire result
+		holder string // lease owner reported by acquire
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"exclusive", []step{
+			{"acquire", "a", time.Minute, true, "a"},
+			{"acquire", "b", time.Minute, false, "a"},
+			{"acquire", "a", time.Minute, false, "a"},
+		}},
+		{"release by non-owner", []step{
+			{"acquire", "a", time.Minute, true, "a"},
+			{"release", "b", 0, false, ""},
+			{"acquire", "b", time.Minute, false, "a"},
+		}},
+		{"release by owner", []step{
+			{"acquire", "a", time.Minute, true, "a"},
+			{"release", "a", 0, false, ""},
+			{"acquire", "b", time.Minute, true, "b"},
+		}},
+		{"release of free key", []step{
+			{"release", "a", 0, false, ""},
+			{"acquire", "a", time.Minute, true, "a"},
+		}},
+		{"expired lease taken over", []step{
+			{"acquire", "a", -time.Second, true, "a"},
+			{"acquire", "b", time.Minute, true, "b"},
+			{"acquire", "a", time.Minute, false, "b"},
+		}},
+	}
+	for kind, newLocker := range testLockers(t) {
+		for _, tt := range tests {
+			t.Run(kind+"/"+tt.name, func(t *testing.T) {
+				l := newLocker()
+				for i, s := range tt.steps {
+					if s.op == "release" {
+						if err := l.Release(ctx, "k", s.owner); err != nil {
+							t.Fatalf("step %d: Release: %v", i, err)
+						}
+						continue
+					}
+					ok, got, err := l.Acquire(ctx, "k", lease(s.owner, s.ttl))
+					if err != nil {
+						t.Fatalf("step %d: Acquire: %v", i, err)
+					}
+					if ok != s.ok || got.Owner != s.holder {
+						t.Fatalf("step %d: Acquire(%s) = %v, %q; want %v, %q", i, s.owner, ok, got.Owner, s.ok, s.holder)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestLockerRace(t *testing.T) {
+	for kind, newLocker := range testLockers(t) {
+		t.Run(kind, func(t *testing.T) {
+			l := newLocker()
+			var wins atomic.Int64
+			var wg sync.WaitGroup
+			for i := range 16 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					ok, _, err := l.Acquire(context.Background(), "k", lease(string(rune('a'+i)), time.Minute))
+					if err != nil {
+						t.Error(err)
+					}
+					if ok {
+						wins.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+			if got := wins.Load(); got != 1 {
+				t.Errorf("%d goroutines acquired the lock, want 1", got)
+			}
+		})
+	}
+}
+
+func TestFileLockerCorruptLease(t *testing.T) {
+	ctx := context.Background()
+	for _, body := range []string{"", "{", "not json", `{"owner":`} {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "k.lock")
+		if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		l := &bags.FileLocker{Dir: dir}
+		if ok, _, err := l.Acquire(ctx, "k", lease("a", time.Minute)); ok || err != nil {
+			t.Errorf("Acquire over %q = %v, %v; want held", body, ok, err)
+		}
+		if err := l.Release(ctx, "k", "a"); err != nil {
+			t.Errorf("Release over %q = %v", body, err)
+		}
+		if got, _ := os.ReadFile(path); string(got) != body {
+			t.Errorf("lock file over %q rewritten to %q", body, got)
+		}
+	}
+}
+
+func TestReleaseLaunchWithoutGuard(t *testing.T) {
+	c, err := bags.New("test-key", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ReleaseLaunch(context.Background(), "BAGS"); err != nil {
+		t.Errorf("ReleaseLaunch without a guard = %v", err)
+	}
+	c.LaunchGuard = &bags.LaunchGuard{}
+	if err := c.ReleaseLaunch(context.Background(), "BAGS"); err != nil {
+		t.Errorf("ReleaseLaunch without a locker = %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+// ndjson.go
+package bags
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"iter"
+	"net/http"
+)
+
+// -------------------- NDJSON --------------------
+
+// NDJSONOptions configures NDJSON output.
+type NDJSONOptions struct {
+	// FlushEvery flushes buffered records to the underlying writer after
+	// every n records. Zero flushes after each record, which suits piping
+	// into jq; negative flushes only on Flush, for bulk loaders.
+	FlushEvery int
+}
+
+// NDJSONWriter writes values as newline-delimited JSON, one value per line.
+// Output is buffered; call Flush when done. When the underlying writer has
+// a Flush method (bufio.Writer, http.Flusher, ...) it is flushed too, so
+// records reach HTTP clients and pipes as they are written.
+type NDJSONWriter struct {
+	dst     io.Writer
+	bw      *bufio.Writer
+	enc     *json.Encoder
+	every   int
+	pending int
+	count   int
+}
+
+// NewNDJSONWriter returns an NDJSONWriter writing to w. opts may be nil.
+func NewNDJSONWriter(w io.Writer, opts *NDJSONOptions) *NDJSONWriter {
+	bw := bufio.NewWriter(w)
+	nw := &NDJSONWriter{dst: w, bw: bw, enc: json.NewEncoder(bw), every: 1}
+	if opts != nil && opts.FlushEvery != 0 {
+		nw.every = opts.FlushEvery
+	}
+	return nw
+}
+
+// Write encodes v as one line.
+func (w *NDJSONWriter) Write(v any) error {
+	if err := w.enc.Encode(v); err != nil {
+		return err
+	}
+	w.count++
+	w.pending++
+	if w.every > 0 && w.pending >= w.every {
+		return w.Flush()
+	}
+	return nil
+}
+
+// Flush writes buffered records to the underlying writer.
+func (w *NDJSONWriter) Flush() error {
+	w.pending = 0
+	if err := w.bw.Flush(); err != nil {
+		return err
+	}
+	switch f := w.dst.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case http.Flusher:
+		f.Flush()
+	}
+	return nil
+}
+
+// Count returns the number of records written.
+func (w *NDJSONWriter) Count() int { return w.count }
+
+// WriteNDJSON writes each item as one line and flushes.
+func WriteNDJSON[T any](w io.Writer, items []T, opts *NDJSONOptions) error {
+	nw := NewNDJSONWriter(w, opts)
+	for _, it := range items {
+		if err := nw.Write(it); err != nil {
+			return err
+		}
+	}
+	return nw.Flush()
+}
+
+// StreamNDJSON writes items from seq, such as BagsClient.Candles, as they
+// arrive and returns the number written. It stops at the first error from
+// seq or w; records written before the error are flushed.
+func StreamNDJSON[T any](w io.Writer, seq iter.Seq2[T, error], opts *NDJSONOptions) (int, error) {
+	nw := NewNDJSONWriter(w, opts)
+	for it, err := range seq {
+		if err == nil {
+			err = nw.Write(it)
+		}
+		if err != nil {
+			nw.Flush()
+			return nw.Count(), err
+		}
+	}
+	return nw.Count(), nw.Flush()
+}
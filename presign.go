@@ -0,0 +1,172 @@
+// presign.go
+package bags
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// ErrSigningDenied is wrapped by errors from SignTransaction when the
+// PreSignHook rejects a transaction.
+var ErrSigningDenied = errors.New("signing denied")
+
+// SystemProgramID is the Solana System Program.
+var SystemProgramID = PublicKey{}
+
+// -------------------- Pre-Sign Hooks --------------------
+
+// PreSignHook inspects a decoded transaction immediately before the client
+// signs it. Returning an error denies the signature; the transaction is left
+// unsigned. Hooks must not modify tx.
+//
+// The hook runs only inside SignTransaction and SignTransactionBase64.
+// Transaction.PartialSign and Transaction.SignWith sign directly and never
+// consult it, and SDK methods that return transactions (CreateTokenLaunchConfig,
+// LaunchFromTweet, ...) leave them unsigned, so route every signature through
+// the client to enforce a policy.
+type PreSignHook func(ctx context.Context, tx *Transaction) error
+
+// ChainPreSignHooks runs hooks in order and stops at the first denial.
+func ChainPreSignHooks(hooks ...PreSignHook) PreSignHook {
+	return func(ctx context.Context, tx *Transaction) error {
+		for _, h := range hooks {
+			if h == nil {
+				continue
+			}
+			if err := h(ctx, tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AllowPrograms denies transactions that invoke a program not in ids.
+// Programs loaded from address lookup tables cannot be resolved locally and
+// are denied too.
+func AllowPrograms(ids ...PublicKey) PreSignHook {
+	return func(_ context.Context, tx *Transaction) error {
+		for i, ix := range tx.Message.Instructions {
+			pid, ok := tx.Message.ProgramID(ix)
+			if !ok {
+				return fmt.Errorf("instruction %d: program loaded from a lookup table", i)
+			}
+			if !slices.Contains(ids, pid) {
+				return fmt.Errorf("instruction %d: program %s not allowed", i, pid)
+			}
+		}
+		return nil
+	}
+}
+
+// MaxSOLTransfer denies transactions whose System Program instructions move
+// more than limit in total out of signer: transfers, account creation, and
+// nonce withdrawals it authorizes. Instructions it cannot decode, or whose
+// funding account is loaded from a lookup table, are denied too.
+func MaxSOLTransfer(signer PublicKey, limit Lamports) PreSignHook {
+	return func(_ context.Context, tx *Transaction) error {
+		var total Lamports
+		for i, ix := range tx.Message.Instructions {
+			if pid, ok := tx.Message.ProgramID(ix); !ok || pid != SystemProgramID {
+				continue
+			}
+			n, err := systemOutflow(&tx.Message, ix, signer)
+			if err != nil {
+				return fmt.Errorf("instruction %d: %w", i, err)
+			}
+			sum, carry := bits.Add64(uint64(total), uint64(n), 0)
+			if carry != 0 {
+				return fmt.Errorf("instruction %d: transfer total overflows", i)
+			}
+			total = Lamports(sum)
+		}
+		if total > limit {
+			return fmt.Errorf("transfers %s SOL, limit %s SOL", total.SOLString(), limit.SOLString())
+		}
+		return nil
+	}
+}
+
+// SignTransaction runs the client's PreSignHook on tx and, if it allows
+// the transaction, adds the Signer's signature.
+func (c *BagsClient) SignTransaction(ctx context.Context, tx *Transaction) error {
+	if c.Signer == nil {
+		return fmt.Errorf("no signer configured")
+	}
+	if c.PreSignHook != nil {
+		if err := c.PreSignHook(ctx, tx); err != nil {
+			return fmt.Errorf("%w: %w", ErrSigningDenied, err)
+		}
+	}
+	return tx.SignWith(c.Signer)
+}
+
+// SignTransactionBase64 decodes a base64 transaction, signs it with
+// SignTransaction, and re-encodes it.
+func (c *BagsClient) SignTransactionBase64(ctx context.Context, b64 string) (string, error) {
+	tx, err := DecodeTransaction(b64)
+	if err != nil {
+		return "", err
+	}
+	if err := c.SignTransaction(ctx, tx); err != nil {
+		return "", err
+	}
+	return tx.Base64()
+}
+
+// ------- Internal Helpers -------
+
+// System Program instructions that move lamports.
+const (
+	sysCreateAccount         = 0
+	sysTransfer              = 2
+	sysCreateAccountWithSeed = 3
+	sysWithdrawNonceAccount  = 5
+	sysTransferWithSeed      = 11
+)
+
+// systemOutflow returns the lamports a System Program instruction moves out
+// of accounts controlled by signer.
+func systemOutflow(m *Message, ix CompiledInstruction, signer PublicKey) (Lamports, error) {
+	d := ix.Data
+	if len(d) < 4 {
+		return 0, errors.New("malformed system instruction")
+	}
+	// Lamports offset, and the account that funds or authorizes the move.
+	var off, funder int
+	switch binary.LittleEndian.Uint32(d) {
+	case sysCreateAccount, sysTransfer:
+		off, funder = 4, 0
+	case sysCreateAccountWithSeed:
+		// base pubkey, then a u64-prefixed seed string.
+		if len(d) < 44 {
+			return 0, errors.New("malformed system instruction")
+		}
+		seed := binary.LittleEndian.Uint64(d[36:44])
+		if seed > uint64(len(d)) {
+			return 0, errors.New("malformed system instruction")
+		}
+		off, funder = 44+int(seed), 0
+	case sysWithdrawNonceAccount:
+		off, funder = 4, 4 // nonce authority
+	case sysTransferWithSeed:
+		off, funder = 4, 1 // base of the derived source
+	default:
+		return 0, nil
+	}
+	if len(d) < off+8 || funder >= len(ix.Accounts) {
+		return 0, errors.New("malformed system instruction")
+	}
+	idx := int(ix.Accounts[funder])
+	if idx >= len(m.AccountKeys) {
+		return 0, errors.New("funding account loaded from a lookup table")
+	}
+	if m.AccountKeys[idx] != signer {
+		return 0, nil
+	}
+	return Lamports(binary.LittleEndian.Uint64(d[off : off+8])), nil
+}
@@ -0,0 +1,124 @@
+// presign_test.go
+package bags_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+// sysIx encodes a System Program instruction: a u32 tag, optional prefix
+// bytes, then a u64 lamport amount.
+func sysIx(tag uint32, prefix []byte, lamports uint64, accounts ...uint8) bags.CompiledInstruction {
+	d := binary.LittleEndian.AppendUint32(nil, tag)
+	d = append(d, prefix...)
+	d = binary.LittleEndian.AppendUint64(d, lamports)
+	return bags.CompiledInstruction{ProgramIDIndex: 2, Accounts: accounts, Data: d}
+}
+
+// seedPrefix is the base pubkey and u64-prefixed seed of CreateAccountWithSeed.
+func seedPrefix(seed string) []byte {
+	p := make([]byte, 32)
+	p = binary.LittleEndian.AppendUint64(p, uint64(len(seed)))
+	return append(p, seed...)
+}
+
+func TestMaxSOLTransfer(t *testing.T) {
+	_, payer := testKey(1)
+	const limit = bags.LamportsPerSOL
+	tests := []struct {
+		name string
+		ixs  []bags.CompiledInstruction
+		ok   bool
+	}{
+		{"transfer within limit", []bags.CompiledInstruction{sysIx(2, nil, uint64(limit), 0, 1)}, true},
+		{"transfer over limit", []bags.CompiledInstruction{sysIx(2, nil, uint64(limit)+1, 0, 1)}, false},
+		{"transfers summed", []bags.CompiledInstruction{
+			sysIx(2, nil, uint64(limit)/2, 0, 1), sysIx(2, nil, uint64(limit)/2+1, 0, 1),
+		}, false},
+		{"create account", []bags.CompiledInstruction{sysIx(0, nil, uint64(limit)+1, 0, 1)}, false},
+		{"create account with seed", []bags.CompiledInstruction{sysIx(3, seedPrefix("vault"), uint64(limit)+1, 0, 1)}, false},
+		{"create account with seed in limit", []bags.CompiledInstruction{sysIx(3, seedPrefix("vault"), 1, 0, 1)}, true},
+		{"withdraw nonce by signer", []bags.CompiledInstruction{sysIx(5, nil, uint64(limit)+1, 1, 1, 1, 1, 0)}, false},
+		{"transfer with seed from signer base", []bags.CompiledInstruction{sysIx(11, nil, uint64(limit)+1, 1, 0, 1)}, false},
+		{"non-signer funder", []bags.CompiledInstruction{sysIx(2, nil, uint64(limit)+1, 1, 0)}, true},
+		{"other system instruction", []bags.CompiledInstruction{sysIx(1, nil, math.MaxUint64, 0)}, true},
+		{"total overflows", []bags.CompiledInstruction{
+			sysIx(2, nil, math.MaxUint64, 0, 1), sysIx(2, nil, 2, 0, 1),
+		}, false},
+		{"data too short for tag", []bags.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []uint8{0, 1}, Data: []byte{2, 0}}}, false},
+		{"data too short for lamports", []bags.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []uint8{0, 1}, Data: []byte{2, 0, 0, 0, 1}}}, false},
+		{"seed length past data", []bags.CompiledInstruction{sysIx(3, append(make([]byte, 32), 0xff, 0xff, 0, 0, 0, 0, 0, 0), 1, 0, 1)}, false},
+		{"missing funder account", []bags.CompiledInstruction{sysIx(2, nil, 1)}, false},
+		{"funder from lookup table", []bags.CompiledInstruction{sysIx(2, nil, 1, 5, 1)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := testTx(payer, true)
+			tx.Message.Instructions = tt.ixs
+			err := bags.MaxSOLTransfer(payer, limit)(context.Background(), tx)
+			if (err == nil) != tt.ok {
+				t.Errorf("MaxSOLTransfer() = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAllowPrograms(t *testing.T) {
+	_, payer := testKey(1)
+	tests := []struct {
+		name  string
+		index uint8
+		ok    bool
+	}{
+		{"allowed", 2, true},
+		{"not allowed", 1, false},
+		{"lookup table program", 3, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := testTx(payer, true)
+			tx.Message.Instructions[0].ProgramIDIndex = tt.index
+			err := bags.AllowPrograms(bags.SystemProgramID)(context.Background(), tx)
+			if (err == nil) != tt.ok {
+				t.Errorf("AllowPrograms() = %v, want ok = %v", err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSignTransactionHook(t *testing.T) {
+	key, payer := testKey(1)
+	deny := errors.New("no")
+	tests := []struct {
+		name   string
+		hook   bags.PreSignHook
+		denied bool
+	}{
+		{"no hook", nil, false},
+		{"allowing chain", bags.ChainPreSignHooks(nil, bags.AllowPrograms(bags.SystemProgramID)), false},
+		{"denying chain", bags.ChainPreSignHooks(bags.AllowPrograms(bags.SystemProgramID), func(context.Context, *bags.Transaction) error { return deny }), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := bags.New("test-key", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.Signer = bags.KeypairSigner(key)
+			c.PreSignHook = tt.hook
+			tx := testTx(payer, false)
+			err = c.SignTransaction(context.Background(), tx)
+			if got := errors.Is(err, bags.ErrSigningDenied); got != tt.denied {
+				t.Fatalf("SignTransaction() = %v, want denied = %v", err, tt.denied)
+			}
+			if signed := tx.Signature() != (bags.Signature{}); signed == tt.denied {
+				t.Errorf("signed = %v after denied = %v", signed, tt.denied)
+			}
+		})
+	}
+}
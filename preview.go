@@ -0,0 +1,283 @@
+// preview.go
+package bags
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// EstimatedSignatureFee is the base network fee per transaction signature.
+const EstimatedSignatureFee Lamports = 5000
+
+// -------------------- Launch Preview --------------------
+
+// LaunchPreviewParams describes a launch that has not been submitted yet.
+type LaunchPreviewParams struct {
+	// Token is the metadata that will be sent to create-token-info. When
+	// Token.Image is set it is read to compute the image hash and replaced
+	// with an equivalent in-memory reader, so Token can still be submitted.
+	Token *CreateTokenInfoRequest
+	// FeeShare is the planned fee allocation. Empty means all fees go to the
+	// launch wallet.
+	FeeShare []FeeShareRecipient
+	// InitialBuy is the planned initial buy.
+	InitialBuy Lamports
+}
+
+// LaunchPreview is a structured summary of a launch for confirmation prompts.
+type LaunchPreview struct {
+	// Metadata is the JSON form of the token metadata fields to be uploaded.
+	Metadata    json.RawMessage `json:"metadata"`
+	Socials     []PreviewSocial `json:"socials"`
+	Splits      []PreviewSplit  `json:"splits"`
+	Costs       PreviewCosts    `json:"costs"`
+	Image       PreviewImage    `json:"image"`
+	Warnings    []string        `json:"warnings,omitempty"`
+	TokenName   string          `json:"tokenName"`
+	TokenSymbol string          `json:"tokenSymbol"`
+}
+
+// PreviewSocial is a social link resolved to a canonical URL.
+type PreviewSocial struct {
+	Kind   string `json:"kind"` // "twitter", "telegram", or "website"
+	Handle string `json:"handle,omitempty"`
+	URL    string `json:"url"`
+}
+
+// PreviewSplit is one fee share allocation.
+type PreviewSplit struct {
+	Recipient string `json:"recipient"` // wallet or @handle
+	Bps       int64  `json:"bps"`
+	Percent   string `json:"percent"`
+}
+
+// PreviewCosts estimates what the launch wallet will spend.
+type PreviewCosts struct {
+	InitialBuy  Lamports `json:"initialBuy"`
+	NetworkFees Lamports `json:"networkFees"` // signature fees only; excludes rent and priority fees
+	Total       Lamports `json:"total"`
+}
+
+// PreviewImage describes the image that will be uploaded.
+type PreviewImage struct {
+	Filename string `json:"filename"`
+	MIMEType string `json:"mimeType"`
+	Bytes    int    `json:"bytes"`
+	SHA256   string `json:"sha256"`
+}
+
+// RenderLaunchPreview builds a preview of a launch without making any
+// network calls. Problems that would not stop the API but are likely
+// mistakes (bps not summing to 100%, missing image, unparsable socials) are
+// reported as Warnings.
+func RenderLaunchPreview(p LaunchPreviewParams) (*LaunchPreview, error) {
+	in := p.Token
+	if in == nil {
+		return nil, fmt.Errorf("nil token")
+	}
+	if strings.TrimSpace(in.Name) == "" || strings.TrimSpace(in.Symbol) == "" {
+		return nil, fmt.Errorf("name and symbol are required")
+	}
+	out := &LaunchPreview{TokenName: in.Name, TokenSymbol: in.Symbol}
+
+	meta := map[string]string{"name": in.Name, "symbol": in.Symbol}
+	for k, v := range map[string]string{
+		"description": in.Description, "telegram": in.Telegram, "twitter": in.Twitter, "website": in.Website,
+	} {
+		if strings.TrimSpace(v) != "" {
+			meta[k] = v
+		}
+	}
+	var err error
+	if out.Metadata, err = json.MarshalIndent(meta, "", "  "); err != nil {
+		return nil, err
+	}
+
+	for _, s := range []struct{ kind, raw string }{
+		{"twitter", in.Twitter}, {"telegram", in.Telegram}, {"website", in.Website},
+	} {
+		if strings.TrimSpace(s.raw) == "" {
+			continue
+		}
+		social, err := resolveSocial(s.kind, s.raw)
+		if err != nil {
+			out.Warnings = append(out.Warnings, err.Error())
+			continue
+		}
+		out.Socials = append(out.Socials, social)
+	}
+
+	if len(p.FeeShare) == 0 {
+		out.Splits = []PreviewSplit{{Recipient: "launch wallet", Bps: TotalBps, Percent: bpsPercent(TotalBps)}}
+	} else {
+		if err := validateFeeShareRecipients(p.FeeShare); err != nil {
+			out.Warnings = append(out.Warnings, err.Error())
+		}
+		for _, r := range p.FeeShare {
+			who := strings.TrimSpace(r.Wallet)
+			if h := strings.TrimSpace(r.TwitterUsername); h != "" {
+				who = "@" + strings.TrimPrefix(h, "@")
+			}
+			out.Splits = append(out.Splits, PreviewSplit{Recipient: who, Bps: r.Bps, Percent: bpsPercent(r.Bps)})
+		}
+	}
+
+	// Config tx (launch wallet), launch tx (launch wallet + mint), and the
+	// fee share config tx when a custom split is used.
+	sigs := Lamports(3)
+	if len(p.FeeShare) > 0 {
+		sigs++
+	}
+	out.Costs = PreviewCosts{
+		InitialBuy:  p.InitialBuy,
+		NetworkFees: sigs * EstimatedSignatureFee,
+	}
+	out.Costs.Total = out.Costs.InitialBuy + out.Costs.NetworkFees
+
+	if in.Image == nil {
+		out.Warnings = append(out.Warnings, "no image provided")
+	} else {
+		data, err := io.ReadAll(in.Image)
+		if err != nil {
+			return nil, fmt.Errorf("read image: %w", err)
+		}
+		in.Image = bytes.NewReader(data)
+		sum := sha256.Sum256(data)
+		mt := in.ImageMIMEType
+		if mt == "" {
+			mt = http.DetectContentType(data)
+		}
+		out.Image = PreviewImage{
+			Filename: in.ImageFilename,
+			MIMEType: mt,
+			Bytes:    len(data),
+			SHA256:   hex.EncodeToString(sum[:]),
+		}
+		if int64(len(data)) > DefaultMaxImageBytes {
+			out.Warnings = append(out.Warnings, fmt.Sprintf("image is %d bytes, larger than %d", len(data), DefaultMaxImageBytes))
+		}
+	}
+	return out, nil
+}
+
+// Text renders the preview as plain text.
+func (p *LaunchPreview) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Launch preview: %s (%s)\n", p.TokenName, p.TokenSymbol)
+	if len(p.Socials) > 0 {
+		b.WriteString("\nSocials:\n")
+		for _, s := range p.Socials {
+			fmt.Fprintf(&b, "  %-9s %s\n", s.Kind, s.URL)
+		}
+	}
+	b.WriteString("\nFee split:\n")
+	for _, s := range p.Splits {
+		fmt.Fprintf(&b, "  %-8s %s\n", s.Percent, s.Recipient)
+	}
+	b.WriteString("\nEstimated cost:\n")
+	fmt.Fprintf(&b, "  initial buy   %s SOL\n", p.Costs.InitialBuy.SOLString())
+	fmt.Fprintf(&b, "  network fees  %s SOL\n", p.Costs.NetworkFees.SOLString())
+	fmt.Fprintf(&b, "  total         %s SOL\n", p.Costs.Total.SOLString())
+	if p.Image.SHA256 != "" {
+		fmt.Fprintf(&b, "\nImage: %s (%s, %d bytes)\n  sha256 %s\n", p.Image.Filename, p.Image.MIMEType, p.Image.Bytes, p.Image.SHA256)
+	}
+	if len(p.Warnings) > 0 {
+		b.WriteString("\nWarnings:\n")
+		for _, w := range p.Warnings {
+			fmt.Fprintf(&b, "  - %s\n", w)
+		}
+	}
+	return b.String()
+}
+
+// Markdown renders the preview for chat messages.
+func (p *LaunchPreview) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Launch preview: %s (`%s`)**\n", mdEscape(p.TokenName), p.TokenSymbol)
+	if len(p.Socials) > 0 {
+		b.WriteString("\n**Socials**\n")
+		for _, s := range p.Socials {
+			fmt.Fprintf(&b, "- %s: %s\n", s.Kind, s.URL)
+		}
+	}
+	b.WriteString("\n**Fee split**\n")
+	for _, s := range p.Splits {
+		fmt.Fprintf(&b, "- %s → `%s`\n", s.Percent, s.Recipient)
+	}
+	b.WriteString("\n**Estimated cost**\n")
+	fmt.Fprintf(&b, "- Initial buy: %s SOL\n", p.Costs.InitialBuy.SOLString())
+	fmt.Fprintf(&b, "- Network fees: %s SOL\n", p.Costs.NetworkFees.SOLString())
+	fmt.Fprintf(&b, "- **Total: %s SOL**\n", p.Costs.Total.SOLString())
+	if p.Image.SHA256 != "" {
+		fmt.Fprintf(&b, "\n**Image** `%s` (%d bytes)\n`sha256 %s`\n", p.Image.Filename, p.Image.Bytes, p.Image.SHA256)
+	}
+	if len(p.Warnings) > 0 {
+		b.WriteString("\n**Warnings**\n")
+		for _, w := range p.Warnings {
+			fmt.Fprintf(&b, "- ⚠️ %s\n", mdEscape(w))
+		}
+	}
+	return b.String()
+}
+
+// ------- Internal Helpers -------
+
+// resolveSocial normalizes a twitter/telegram handle or URL, or a website.
+func resolveSocial(kind, raw string) (PreviewSocial, error) {
+	raw = strings.TrimSpace(raw)
+	s := PreviewSocial{Kind: kind}
+	switch kind {
+	case "twitter", "telegram":
+		host := "x.com"
+		if kind == "telegram" {
+			host = "t.me"
+		}
+		handle := strings.TrimPrefix(raw, "@")
+		if strings.Contains(raw, "/") {
+			u, err := url.Parse(withScheme(raw))
+			if err != nil || u.Host == "" {
+				return s, fmt.Errorf("%s: cannot parse %q", kind, raw)
+			}
+			parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+			if parts[0] == "" {
+				return s, fmt.Errorf("%s: no handle in %q", kind, raw)
+			}
+			handle = parts[0]
+			if kind == "twitter" && len(parts) > 1 {
+				// Tweet links resolve to themselves, not the profile.
+				s.Handle, s.URL = handle, "https://"+host+"/"+strings.Join(parts, "/")
+				return s, nil
+			}
+		}
+		s.Handle, s.URL = handle, "https://"+host+"/"+handle
+	case "website":
+		u, err := url.Parse(withScheme(raw))
+		if err != nil || u.Host == "" {
+			return s, fmt.Errorf("website: cannot parse %q", raw)
+		}
+		s.URL = u.String()
+	}
+	return s, nil
+}
+
+func withScheme(raw string) string {
+	if strings.Contains(raw, "://") {
+		return raw
+	}
+	return "https://" + raw
+}
+
+func bpsPercent(bps int64) string {
+	return fmt.Sprintf("%d.%02d%%", bps/100, bps%100)
+}
+
+var mdEscaper = strings.NewReplacer("*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+
+func mdEscape(s string) string { return mdEscaper.Replace(s) }
@@ -0,0 +1,161 @@
+// quote.go
+package bags
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrQuoteMintUnsupported matches a QuoteMintError returned when the API
+// rejects a quote mint other than wSOL.
+var ErrQuoteMintUnsupported = errors.New("quote mint not supported by the api")
+
+// -------------------- Quote Assets --------------------
+
+// QuoteAsset is a mint that launches and fee share configs can be quoted in.
+type QuoteAsset struct {
+	Mint     string
+	Symbol   string
+	Decimals uint8
+}
+
+// Well-known quote assets.
+var (
+	QuoteWSOL = QuoteAsset{Mint: WrappedSOLMint, Symbol: "SOL", Decimals: 9}
+	QuoteUSDC = QuoteAsset{Mint: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Symbol: "USDC", Decimals: 6}
+)
+
+// Amount parses a human-readable amount of the asset, e.g. "12.5".
+func (q QuoteAsset) Amount(s string) (TokenAmount, error) {
+	return ParseTokenAmount(s, q.Decimals)
+}
+
+// TokenAmount is an amount of a mint in its base units, together with the
+// mint's decimals. It is the quote-mint counterpart of Lamports.
+type TokenAmount struct {
+	Units    uint64 `json:"units"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// ParseTokenAmount parses a decimal amount of a mint with the given
+// decimals. Amounts with more fractional digits than the mint supports, or
+// that overflow 64 bits of base units, are rejected.
+func ParseTokenAmount(s string, decimals uint8) (TokenAmount, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" || strings.Trim(whole+frac, "0123456789") != "" {
+		return TokenAmount{}, fmt.Errorf("invalid token amount %q", s)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > int(decimals) {
+		return TokenAmount{}, fmt.Errorf("token amount %q has more than %d decimals", s, decimals)
+	}
+	n, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", int(decimals)-len(frac)), 10)
+	if !ok || !n.IsUint64() {
+		return TokenAmount{}, fmt.Errorf("token amount %q overflows uint64", s)
+	}
+	return TokenAmount{Units: n.Uint64(), Decimals: decimals}, nil
+}
+
+// Decimal returns the amount in whole tokens.
+func (a TokenAmount) Decimal() Decimal {
+	d, _ := ParseDecimal(a.String())
+	return d
+}
+
+// String formats the amount in whole tokens exactly, without trailing zeros.
+func (a TokenAmount) String() string {
+	s := strconv.FormatUint(a.Units, 10)
+	if a.Decimals == 0 {
+		return s
+	}
+	d := int(a.Decimals)
+	if len(s) <= d {
+		s = strings.Repeat("0", d-len(s)+1) + s
+	}
+	whole, frac := s[:len(s)-d], strings.TrimRight(s[len(s)-d:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// -------------------- Quote Mint Support --------------------
+
+// QuoteMintSupport is what the client has observed about the API's support
+// for quote mints other than wSOL.
+type QuoteMintSupport int
+
+const (
+	QuoteMintsUnknown  QuoteMintSupport = iota // no alternative quote mint sent yet
+	QuoteMintsWSOLOnly                         // the API rejected an alternative quote mint
+	QuoteMintsAny                              // the API accepted an alternative quote mint
+)
+
+func (s QuoteMintSupport) String() string {
+	switch s {
+	case QuoteMintsUnknown:
+		return "unknown"
+	case QuoteMintsWSOLOnly:
+		return "wsol-only"
+	case QuoteMintsAny:
+		return "any"
+	}
+	return fmt.Sprintf("QuoteMintSupport(%d)", int(s))
+}
+
+// QuoteMintError reports that the API rejected a non-wSOL quote mint, which
+// older API versions do.
+type QuoteMintError struct {
+	Mint     string
+	Endpoint string
+	Err      error // the API error
+}
+
+func (e *QuoteMintError) Error() string {
+	return fmt.Sprintf("%s: quote mint %s not supported by this api version (only %s): %v", e.Endpoint, e.Mint, WrappedSOLMint, e.Err)
+}
+
+func (e *QuoteMintError) Unwrap() error { return e.Err }
+
+func (e *QuoteMintError) Is(target error) bool { return target == ErrQuoteMintUnsupported }
+
+// QuoteMintSupport reports whether the API has accepted or rejected a quote
+// mint other than wSOL on this client. It starts out QuoteMintsUnknown.
+func (c *BagsClient) QuoteMintSupport() QuoteMintSupport {
+	return QuoteMintSupport(c.quoteSupport.Load())
+}
+
+// ------- Internal Helpers -------
+
+var quoteMintRe = regexp.MustCompile(`(?i)quote[ _-]?mint`)
+
+// observeQuoteMint records the outcome of a request that sent quote and
+// turns rejections of an alternative quote mint into a *QuoteMintError.
+func (c *BagsClient) observeQuoteMint(endpoint, quote string, err error) error {
+	if quote == "" || quote == WrappedSOLMint {
+		return err
+	}
+	if err == nil {
+		c.quoteSupport.Store(int32(QuoteMintsAny))
+		return nil
+	}
+	var ae *apiError
+	if errors.As(err, &ae) && (ae.Status == 0 || ae.Status == 400) && quoteMintRe.MatchString(ae.Message) {
+		c.quoteSupport.Store(int32(QuoteMintsWSOLOnly))
+		return &QuoteMintError{Mint: quote, Endpoint: endpoint, Err: err}
+	}
+	return err
+}
+
+// validateQuoteMint checks a quote mint against the base mint it quotes.
+func validateQuoteMint(quote, base string) error {
+	if strings.TrimSpace(quote) == strings.TrimSpace(base) {
+		return fmt.Errorf("quoteMint must differ from baseMint")
+	}
+	return nil
+}
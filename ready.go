@@ -0,0 +1,64 @@
+// ready.go
+package bags
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultReadyTTL is how long a Ping result is reused by Ready when
+// BagsClient.ReadyTTL is zero.
+const DefaultReadyTTL = 5 * time.Second
+
+// readyCache holds the last Ping outcome shared by Ready callers.
+type readyCache struct {
+	mu       sync.Mutex
+	at       time.Time
+	err      error
+	inflight chan struct{}
+}
+
+// Ready reports whether the API is reachable, for readiness probes. It
+// serves the result of the last Ping, successful or not, for ReadyTTL, and
+// concurrent callers share a single in-flight Ping, so frequent probes cost
+// at most one API call per TTL.
+func (c *BagsClient) Ready(ctx context.Context) error {
+	ttl := c.ReadyTTL
+	if ttl <= 0 {
+		ttl = DefaultReadyTTL
+	}
+	rc := &c.ready
+	for {
+		rc.mu.Lock()
+		if !rc.at.IsZero() && time.Since(rc.at) < ttl {
+			err := rc.err
+			rc.mu.Unlock()
+			return err
+		}
+		if ch := rc.inflight; ch != nil {
+			rc.mu.Unlock()
+			select {
+			case <-ch:
+				continue
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		ch := make(chan struct{})
+		rc.inflight = ch
+		rc.mu.Unlock()
+
+		err := c.Ping(ctx)
+
+		rc.mu.Lock()
+		// A probe that gave up says nothing about the API; don't cache it.
+		if ctx.Err() == nil {
+			rc.at, rc.err = time.Now(), err
+		}
+		rc.inflight = nil
+		close(ch)
+		rc.mu.Unlock()
+		return err
+	}
+}
@@ -0,0 +1,64 @@
+// redirect.go
+package bags
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrCrossHostRedirect is wrapped by errors from redirects to another host
+// that the RedirectPolicy does not allow.
+var ErrCrossHostRedirect = errors.New("cross-host redirect not allowed")
+
+// -------------------- Redirect Policy --------------------
+
+// RedirectPolicy controls how API requests follow redirects. Go's default
+// only strips Authorization and Cookie on cross-domain redirects, not the
+// x-api-key header, so a misconfigured endpoint or proxy could otherwise
+// forward the API key to another host.
+type RedirectPolicy struct {
+	// MaxRedirects is the number of redirects followed per request. Zero
+	// means no redirects are followed.
+	MaxRedirects int
+	// AllowCrossHost follows redirects to other hosts, with the API key and
+	// other credentials removed. When false such redirects fail.
+	AllowCrossHost bool
+}
+
+// DefaultRedirectPolicy follows up to five redirects, same-host only for
+// authenticated requests. New installs it on the client it creates when
+// none is supplied.
+var DefaultRedirectPolicy = RedirectPolicy{MaxRedirects: 5}
+
+// CheckRedirect implements http.Client.CheckRedirect. Install it on a
+// caller-provided client with hc.CheckRedirect = policy.CheckRedirect.
+//
+// The host and scheme rules apply to requests that carry credentials (API
+// requests); unauthenticated fetches such as FetchImage may follow CDN
+// redirects across hosts. HTTPS-to-HTTP downgrades of authenticated
+// requests are always refused.
+func (p RedirectPolicy) CheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > p.MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", p.MaxRedirects)
+	}
+	first := via[0]
+	if first.Header.Get("x-api-key") == "" && first.Header.Get("Authorization") == "" {
+		return nil
+	}
+	orig := first.URL
+	if orig.Scheme == "https" && req.URL.Scheme != "https" {
+		return fmt.Errorf("refusing redirect from https to %s", req.URL.Scheme)
+	}
+	if strings.EqualFold(req.URL.Host, orig.Host) {
+		return nil
+	}
+	if !p.AllowCrossHost {
+		return fmt.Errorf("refusing redirect from %s to %s: %w", orig.Host, req.URL.Host, ErrCrossHostRedirect)
+	}
+	for _, h := range []string{"x-api-key", "Authorization", "Cookie"} {
+		req.Header.Del(h)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// responsehook.go
+package bags
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// -------------------- Response Hooks --------------------
+
+// ResponseEvent describes one successfully decoded API response.
+type ResponseEvent struct {
+	Method   string     // "GET" or "POST"
+	Endpoint string     // endpoint path, e.g. "token-launch/creator/v2"
+	Params   url.Values // query parameters
+	Request  any        // JSON request body of a POST, nil otherwise
+	// Result is the decoded "response" field of the envelope, e.g. a
+	// []TokenCreator or *CreateTokenLaunchTxResult.
+	Result any
+	// Body is the response body as decoded, after envelope normalization.
+	Body []byte
+}
+
+// ResponseHook is called after every successfully decoded API response,
+// including responses served from Cache, e.g. to fill an external cache or
+// publish observed launches to a message queue. It runs on the calling
+// goroutine, so it should hand slow work off; it must not modify the event.
+type ResponseHook func(ctx context.Context, ev ResponseEvent)
+
+// ------- Internal Helpers -------
+
+type requestBodyKey struct{}
+
+// withRequestBody records the JSON body of a request for ResponseHook.
+func withRequestBody(ctx context.Context, body any) context.Context {
+	return context.WithValue(ctx, requestBodyKey{}, body)
+}
+
+func (c *BagsClient) fireResponseHook(req *http.Request, data []byte, v any) {
+	if c.ResponseHook == nil {
+		return
+	}
+	ctx := req.Context()
+	var body any
+	if req.Method == http.MethodPost {
+		body = ctx.Value(requestBodyKey{})
+	}
+	c.ResponseHook(ctx, ResponseEvent{
+		Method:   req.Method,
+		Endpoint: c.endpointPath(req.URL),
+		Params:   req.URL.Query(),
+		Request:  body,
+		Result:   envelopeResponse(v),
+		Body:     data,
+	})
+}
+
+// envelopeResponse returns the field of the struct v points to that is
+// tagged json:"response", or v itself when there is none.
+func envelopeResponse(v any) any {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return v
+	}
+	rv = rv.Elem()
+	for i := range rv.NumField() {
+		if name, _, _ := strings.Cut(rv.Type().Field(i).Tag.Get("json"), ","); name == "response" {
+			return rv.Field(i).Interface()
+		}
+	}
+	return v
+}
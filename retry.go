@@ -0,0 +1,175 @@
+// retry.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how a class of requests is retried after transient
+// failures (network errors, 429, 500, 502, 503, 504).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 mean a single attempt.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles per retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and any server-provided Retry-After.
+	MaxDelay time.Duration
+}
+
+// DefaultReadRetryPolicy retries idempotent GETs aggressively.
+var DefaultReadRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// DefaultWriteRetryPolicy does not retry POSTs, since a retried mutation may
+// be applied twice.
+var DefaultWriteRetryPolicy = RetryPolicy{
+	MaxAttempts: 1,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// send performs req, retrying transient failures according to the policy for
+// req's method. Requests whose body cannot be replayed are sent once. Each
+// attempt waits for the tenant's rate limit and the adaptive throttle and
+// passes through the endpoint's circuit breaker when these are enabled, and
+// feeds ClockSkew.
+func (c *BagsClient) send(req *http.Request) (*http.Response, error) {
+	cfg := c.Config()
+	policy := cfg.WriteRetry
+	if isReadMethod(req.Method) {
+		policy = cfg.ReadRetry
+	}
+	br := c.breakerFor(req, cfg.Breaker)
+	for attempt := 1; ; attempt++ {
+		if err := c.Tenants.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		if err := c.throttleWait(req.Context(), cfg.Throttle); err != nil {
+			return nil, err
+		}
+		if br != nil && !br.allow(cfg.Breaker) {
+			return nil, fmt.Errorf("%s: %w", c.endpointPath(req.URL), ErrCircuitOpen)
+		}
+		if err := takeCallBudget(req.Context()); err != nil {
+			if br != nil {
+				br.release()
+			}
+			return nil, err
+		}
+		sent := time.Now()
+		res, err := c.HTTP.Do(req)
+		c.observeClock(res, sent, time.Now())
+		c.recordStat(req, res)
+		failed := breakerFailure(req, res, err)
+		if br != nil {
+			br.record(cfg.Breaker, failed)
+		}
+		c.throttleRecord(cfg.Throttle, failed)
+		if attempt >= policy.MaxAttempts || !retryable(req.Context(), res, err) || !c.retryableWrite(req, res) {
+			return res, err
+		}
+		next, ok := rewindRequest(req)
+		if !ok {
+			return res, err
+		}
+		wait := policy.backoff(attempt)
+		if res != nil {
+			if ra, ok := retryAfter(res); ok {
+				wait = min(ra, policy.MaxDelay)
+			}
+			_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+			res.Body.Close()
+		}
+		if err := sleepCtx(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// backoff returns a jittered exponential delay before retry number attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// Equal jitter: half fixed, half random.
+	return d/2 + rand.N(d/2+1)
+}
+
+func retryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// rewindRequest returns a copy of req with a fresh body, or false when the
+// body cannot be replayed.
+func rewindRequest(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	next := req.Clone(req.Context())
+	next.Body = body
+	return next, true
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(res *http.Response) (time.Duration, bool) {
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
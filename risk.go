@@ -0,0 +1,328 @@
+// risk.go
+package bags
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Risk signal names used in RiskReport.Signals.
+const (
+	RiskSignalHolderConcentration = "holderConcentration"
+	RiskSignalMintAuthority       = "mintAuthority"
+	RiskSignalCreator             = "creator"
+	RiskSignalSocials             = "socials"
+)
+
+// RiskTopHolders is the number of largest holders counted towards holder
+// concentration.
+const RiskTopHolders = 10
+
+// -------------------- Chain Inspection --------------------
+
+// TokenHolder is one token account owner and balance.
+type TokenHolder struct {
+	Owner  string `json:"owner"`
+	Amount uint64 `json:"amount"` // in the token's base units
+	// Program marks accounts owned by a program, such as the bonding curve
+	// or pool vault. They are excluded from holder concentration.
+	Program bool `json:"program,omitempty"`
+}
+
+// MintInfo is the on-chain state of a token mint, plus the socials listed in
+// its metadata JSON.
+type MintInfo struct {
+	Supply          uint64        `json:"supply"`
+	Decimals        uint8         `json:"decimals"`
+	MintAuthority   string        `json:"mintAuthority,omitempty"`   // empty when revoked
+	FreezeAuthority string        `json:"freezeAuthority,omitempty"` // empty when revoked
+	TopHolders      []TokenHolder `json:"topHolders"`                // largest holders, any order
+	Twitter         string        `json:"twitter,omitempty"`
+	Website         string        `json:"website,omitempty"`
+	Telegram        string        `json:"telegram,omitempty"`
+}
+
+// ChainInspector reads a mint's state from the chain, typically through a
+// Solana RPC node (getAccountInfo, getTokenLargestAccounts) and the metadata
+// URI.
+type ChainInspector interface {
+	InspectMint(ctx context.Context, mint string) (*MintInfo, error)
+}
+
+// ChainInspectorFunc adapts a function to a ChainInspector.
+type ChainInspectorFunc func(ctx context.Context, mint string) (*MintInfo, error)
+
+func (f ChainInspectorFunc) InspectMint(ctx context.Context, mint string) (*MintInfo, error) {
+	return f(ctx, mint)
+}
+
+// -------------------- Risk Signals --------------------
+
+// RiskLevel grades a signal or a whole report.
+type RiskLevel int
+
+const (
+	RiskUnknown RiskLevel = iota // the signal could not be computed
+	RiskLow
+	RiskMedium
+	RiskHigh
+)
+
+func (l RiskLevel) String() string {
+	switch l {
+	case RiskUnknown:
+		return "unknown"
+	case RiskLow:
+		return "low"
+	case RiskMedium:
+		return "medium"
+	case RiskHigh:
+		return "high"
+	}
+	return fmt.Sprintf("RiskLevel(%d)", int(l))
+}
+
+// MarshalText encodes the level as its name.
+func (l RiskLevel) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+// RiskSignal is one explainable component of a RiskReport.
+type RiskSignal struct {
+	Name   string    `json:"name"`
+	Level  RiskLevel `json:"level"`
+	Score  int       `json:"score"`  // 0 (low) to 100 (high)
+	Weight int       `json:"weight"` // share of the report score
+	Reason string    `json:"reason"`
+	Err    error     `json:"-"` // set when Level is RiskUnknown
+}
+
+// RiskReport combines holder concentration, creator fee share, mint
+// authority status, and socials presence for one token.
+//
+// Score is the weighted average of the signals that could be computed;
+// signals whose source failed are reported with RiskUnknown and excluded.
+type RiskReport struct {
+	Mint    string       `json:"mint"`
+	Level   RiskLevel    `json:"level"`
+	Score   int          `json:"score"`
+	Signals []RiskSignal `json:"signals"`
+
+	TopHolderPercent Decimal  `json:"topHolderPercent"` // share of supply held by the top RiskTopHolders
+	MintAuthority    string   `json:"mintAuthority,omitempty"`
+	FreezeAuthority  string   `json:"freezeAuthority,omitempty"`
+	CreatorBps       int64    `json:"creatorBps"` // fee share of creator entries
+	Socials          []string `json:"socials"`
+}
+
+// Signal returns the named signal, or nil.
+func (r *RiskReport) Signal(name string) *RiskSignal {
+	for i := range r.Signals {
+		if r.Signals[i].Name == name {
+			return &r.Signals[i]
+		}
+	}
+	return nil
+}
+
+// ComputeRiskSignals builds a RiskReport for mint from the launch creators
+// and, when BagsClient.ChainInspector is set, the mint's on-chain state.
+// Without an inspector the holder and authority signals are RiskUnknown. It
+// fails only when no signal could be computed.
+func (c *BagsClient) ComputeRiskSignals(ctx context.Context, mint string) (*RiskReport, error) {
+	mint = strings.TrimSpace(mint)
+	if mint == "" {
+		return nil, fmt.Errorf("tokenMint is required")
+	}
+
+	var (
+		wg       sync.WaitGroup
+		info     *MintInfo
+		infoErr  error
+		creators []TokenCreator
+		credErr  error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if c.ChainInspector == nil {
+			infoErr = fmt.Errorf("no chain inspector configured")
+			return
+		}
+		info, infoErr = c.ChainInspector.InspectMint(ctx, mint)
+		if infoErr == nil && info == nil {
+			infoErr = fmt.Errorf("inspect mint: no info returned")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		creators, credErr = c.GetTokenLaunchCreators(ctx, mint)
+	}()
+	wg.Wait()
+
+	r := &RiskReport{Mint: mint}
+	if infoErr == nil {
+		r.MintAuthority, r.FreezeAuthority = info.MintAuthority, info.FreezeAuthority
+		r.TopHolderPercent = topHolderPercent(info)
+	}
+	for _, cr := range creators {
+		if cr.IsCreator {
+			r.CreatorBps += int64(cr.RoyaltyBps)
+		}
+	}
+	r.Socials = riskSocials(info, creators)
+
+	r.Signals = []RiskSignal{
+		holderSignal(r, infoErr),
+		authoritySignal(r, infoErr),
+		creatorSignal(r, creators, credErr),
+		socialsSignal(r, infoErr, credErr),
+	}
+	var sum, weights int
+	var errs []error
+	for _, s := range r.Signals {
+		if s.Level == RiskUnknown {
+			errs = append(errs, fmt.Errorf("%s: %w", s.Name, s.Err))
+			continue
+		}
+		sum += s.Score * s.Weight
+		weights += s.Weight
+	}
+	if weights == 0 {
+		return nil, fmt.Errorf("compute risk signals: %w", errors.Join(errs...))
+	}
+	r.Score = sum / weights
+	r.Level = riskLevel(r.Score)
+	return r, nil
+}
+
+// ------- Internal Helpers -------
+
+func holderSignal(r *RiskReport, err error) RiskSignal {
+	s := RiskSignal{Name: RiskSignalHolderConcentration, Weight: 35}
+	if err != nil {
+		return unknownSignal(s, err)
+	}
+	pct := r.TopHolderPercent.Float64()
+	s.Score = min(100, int(pct*100/60)) // 60% or more in the top holders is maximal risk
+	s.Level = riskLevel(s.Score)
+	s.Reason = fmt.Sprintf("top %d holders own %.1f%% of supply", RiskTopHolders, pct)
+	return s
+}
+
+func authoritySignal(r *RiskReport, err error) RiskSignal {
+	s := RiskSignal{Name: RiskSignalMintAuthority, Weight: 30}
+	if err != nil {
+		return unknownSignal(s, err)
+	}
+	switch {
+	case r.MintAuthority != "":
+		s.Score, s.Reason = 100, "mint authority "+r.MintAuthority+" can mint more supply"
+	case r.FreezeAuthority != "":
+		s.Score, s.Reason = 70, "freeze authority "+r.FreezeAuthority+" can freeze holder accounts"
+	default:
+		s.Score, s.Reason = 0, "mint and freeze authorities are revoked"
+	}
+	s.Level = riskLevel(s.Score)
+	return s
+}
+
+func creatorSignal(r *RiskReport, creators []TokenCreator, err error) RiskSignal {
+	s := RiskSignal{Name: RiskSignalCreator, Weight: 15}
+	if err != nil {
+		return unknownSignal(s, err)
+	}
+	i := slices.IndexFunc(creators, func(cr TokenCreator) bool { return cr.IsCreator })
+	switch {
+	case i < 0:
+		s.Score, s.Reason = 100, "no creator is listed for the launch"
+	case strings.TrimSpace(creators[i].TwitterUsername) == "":
+		s.Score, s.Reason = 60, fmt.Sprintf("creator %s has no linked Twitter account and receives %s of fees", creators[i].Wallet, bpsPercent(r.CreatorBps))
+	default:
+		s.Score, s.Reason = 0, fmt.Sprintf("creator @%s receives %s of fees", creators[i].TwitterUsername, bpsPercent(r.CreatorBps))
+	}
+	s.Level = riskLevel(s.Score)
+	return s
+}
+
+func socialsSignal(r *RiskReport, infoErr, credErr error) RiskSignal {
+	s := RiskSignal{Name: RiskSignalSocials, Weight: 20}
+	if infoErr != nil && credErr != nil {
+		return unknownSignal(s, errors.Join(infoErr, credErr))
+	}
+	switch n := len(r.Socials); {
+	case n == 0:
+		s.Score, s.Reason = 100, "no socials are linked"
+	case n == 1:
+		s.Score, s.Reason = 50, "only "+r.Socials[0]+" is linked"
+	default:
+		s.Score, s.Reason = 0, strings.Join(r.Socials, ", ")+" are linked"
+	}
+	if infoErr != nil {
+		s.Reason += " (token metadata unavailable)"
+	}
+	s.Level = riskLevel(s.Score)
+	return s
+}
+
+func unknownSignal(s RiskSignal, err error) RiskSignal {
+	s.Level, s.Err, s.Reason = RiskUnknown, err, err.Error()
+	return s
+}
+
+func riskLevel(score int) RiskLevel {
+	switch {
+	case score >= 67:
+		return RiskHigh
+	case score >= 34:
+		return RiskMedium
+	}
+	return RiskLow
+}
+
+// topHolderPercent returns the share of supply, in percent, held by the
+// largest RiskTopHolders non-program holders, to 4 decimal places.
+func topHolderPercent(info *MintInfo) Decimal {
+	if info.Supply == 0 {
+		return Decimal{}
+	}
+	amounts := make([]uint64, 0, len(info.TopHolders))
+	for _, h := range info.TopHolders {
+		if !h.Program {
+			amounts = append(amounts, h.Amount)
+		}
+	}
+	slices.SortFunc(amounts, func(a, b uint64) int { return cmp.Compare(b, a) })
+	held := new(big.Int)
+	for _, a := range amounts[:min(len(amounts), RiskTopHolders)] {
+		held.Add(held, new(big.Int).SetUint64(a))
+	}
+	pct := new(big.Rat).SetFrac(held.Mul(held, big.NewInt(100)), new(big.Int).SetUint64(info.Supply))
+	return RatDecimal(pct, 4)
+}
+
+// riskSocials lists the kinds of socials linked by the token metadata or the
+// creator's profile.
+func riskSocials(info *MintInfo, creators []TokenCreator) []string {
+	var out []string
+	add := func(kind, v string) {
+		if strings.TrimSpace(v) != "" && !slices.Contains(out, kind) {
+			out = append(out, kind)
+		}
+	}
+	if info != nil {
+		add("twitter", info.Twitter)
+		add("website", info.Website)
+		add("telegram", info.Telegram)
+	}
+	for _, cr := range creators {
+		if cr.IsCreator {
+			add("twitter", cr.TwitterUsername)
+		}
+	}
+	return out
+}
@@ -0,0 +1,317 @@
+// selftest.go
+package bags
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Self-test check names, in the order SelfTest runs them.
+const (
+	CheckConnectivity = "connectivity"
+	CheckClockSkew    = "clockSkew"
+	CheckAPIKey       = "apiKey"
+	CheckQuota        = "quota"
+	CheckRPC          = "rpc"
+	CheckSigner       = "signer"
+)
+
+// Self-test defaults used when the SelfTestOptions fields are zero.
+const (
+	DefaultMaxClockSkew      = 30 * time.Second
+	DefaultMinQuotaRemaining = 50
+)
+
+// -------------------- Self Test --------------------
+
+// CheckStatus is the outcome of one self-test check.
+type CheckStatus int
+
+const (
+	CheckPass CheckStatus = iota
+	CheckWarn
+	CheckFail
+	CheckSkip // not applicable, e.g. no signer configured
+)
+
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckPass:
+		return "pass"
+	case CheckWarn:
+		return "warn"
+	case CheckFail:
+		return "fail"
+	case CheckSkip:
+		return "skip"
+	}
+	return fmt.Sprintf("CheckStatus(%d)", int(s))
+}
+
+// MarshalText encodes the status as its name.
+func (s CheckStatus) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// CheckResult is the outcome of one self-test check. Fix suggests what to
+// do when the check did not pass.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Status   CheckStatus   `json:"status"`
+	Detail   string        `json:"detail"`
+	Fix      string        `json:"fix,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// SelfTestReport lists the results of SelfTest.
+type SelfTestReport struct {
+	Checks []CheckResult `json:"checks"`
+}
+
+// OK reports whether no check failed. Warnings do not count as failures.
+func (r *SelfTestReport) OK() bool {
+	for _, c := range r.Checks {
+		if c.Status == CheckFail {
+			return false
+		}
+	}
+	return true
+}
+
+// SelfTestOptions configures SelfTest.
+type SelfTestOptions struct {
+	// RPCURL is a Solana JSON-RPC endpoint probed with getHealth. Empty
+	// skips the RPC check.
+	RPCURL string
+	// MaxClockSkew is the largest tolerated difference between the local
+	// clock and the API's Date header. Zero means the client's
+	// MaxClockSkew, or DefaultMaxClockSkew.
+	MaxClockSkew time.Duration
+	// MinQuotaRemaining warns when fewer requests than this are left in the
+	// current rate limit window. Zero means DefaultMinQuotaRemaining.
+	MinQuotaRemaining int
+}
+
+// SelfTest verifies the client is ready for a launch: API connectivity,
+// clock skew against the API, API key validity, rate limit headroom, Solana
+// RPC reachability, and the configured Signer. Every check runs even when an
+// earlier one fails; use OK on the report to gate a scheduled launch. opts
+// may be nil.
+func (c *BagsClient) SelfTest(ctx context.Context, opts *SelfTestOptions) *SelfTestReport {
+	var o SelfTestOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxClockSkew <= 0 {
+		o.MaxClockSkew = c.MaxClockSkew
+	}
+	if o.MaxClockSkew <= 0 {
+		o.MaxClockSkew = DefaultMaxClockSkew
+	}
+	if o.MinQuotaRemaining <= 0 {
+		o.MinQuotaRemaining = DefaultMinQuotaRemaining
+	}
+
+	r := &SelfTestReport{}
+	run := func(name string, check func() CheckResult) {
+		start := time.Now()
+		res := check()
+		res.Name, res.Duration = name, time.Since(start)
+		r.Checks = append(r.Checks, res)
+	}
+
+	var skew time.Duration
+	var skewOK bool
+	run(CheckConnectivity, func() CheckResult {
+		var res CheckResult
+		res, skew, skewOK = c.checkConnectivity(ctx)
+		return res
+	})
+	run(CheckClockSkew, func() CheckResult { return checkSkew(skew, skewOK, o.MaxClockSkew) })
+
+	var quota http.Header
+	run(CheckAPIKey, func() CheckResult {
+		var res CheckResult
+		res, quota = c.checkAPIKey(ctx)
+		return res
+	})
+	run(CheckQuota, func() CheckResult { return checkQuota(ctx, quota, o.MinQuotaRemaining) })
+	run(CheckRPC, func() CheckResult { return c.checkRPC(ctx, o.RPCURL) })
+	run(CheckSigner, c.checkSigner)
+	return r
+}
+
+// ------- Internal Helpers -------
+
+func (c *BagsClient) checkConnectivity(ctx context.Context) (CheckResult, time.Duration, bool) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/ping", nil, "")
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: err.Error(), Fix: "check the configured base URL"}, 0, false
+	}
+	sent := time.Now()
+	res, err := c.send(req)
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: err.Error(), Fix: "check network access to " + req.URL.Host + " and the base URL"}, 0, false
+	}
+	defer res.Body.Close()
+	received := time.Now()
+	skew, skewOK := dateSkew(res.Header, sent, received)
+
+	var out struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	if res.StatusCode != http.StatusOK || json.Unmarshal(data, &out) != nil || !strings.EqualFold(out.Message, "pong") {
+		return CheckResult{Status: CheckFail, Detail: fmt.Sprintf("ping returned %s", res.Status), Fix: "check the base URL points at the Bags API"}, skew, skewOK
+	}
+	return CheckResult{Status: CheckPass, Detail: fmt.Sprintf("%s answered in %s", req.URL.Host, received.Sub(sent).Round(time.Millisecond))}, skew, skewOK
+}
+
+// dateSkew estimates how far the server clock is ahead of the local clock
+// from a response's Date header, taking the request midpoint as the local
+// time. The header only has one-second resolution.
+func dateSkew(h http.Header, sent, received time.Time) (time.Duration, bool) {
+	d, err := http.ParseTime(h.Get("Date"))
+	if err != nil {
+		return 0, false
+	}
+	mid := sent.Add(received.Sub(sent) / 2)
+	return d.Sub(mid.Truncate(time.Second)), true
+}
+
+func checkSkew(skew time.Duration, ok bool, limit time.Duration) CheckResult {
+	if !ok {
+		return CheckResult{Status: CheckSkip, Detail: "no usable Date header from the API"}
+	}
+	abs := skew.Abs()
+	var detail string
+	switch {
+	case abs <= time.Second:
+		detail = "local clock matches the API"
+	case skew > 0:
+		detail = fmt.Sprintf("local clock is %s behind the API", abs)
+	default:
+		detail = fmt.Sprintf("local clock is %s ahead of the API", abs)
+	}
+	if abs > limit {
+		return CheckResult{Status: CheckFail, Detail: detail, Fix: "synchronize the system clock (e.g. enable NTP)"}
+	}
+	return CheckResult{Status: CheckPass, Detail: detail}
+}
+
+// checkAPIKey makes a cheap authenticated read and classifies the status.
+// It returns the response headers for the quota check.
+func (c *BagsClient) checkAPIKey(ctx context.Context) (CheckResult, http.Header) {
+	req, err := c.newRequest(ctx, http.MethodGet, "token-launch/lifetime-fees?tokenMint="+url.QueryEscape(WrappedSOLMint), nil, "")
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: err.Error()}, nil
+	}
+	res, err := c.send(req)
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: err.Error(), Fix: "check network access to the API"}, nil
+	}
+	defer res.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 1<<20))
+	var ae apiError
+	_ = json.Unmarshal(c.normalizeEnvelope(c.endpointPath(req.URL), res.StatusCode, data), &ae)
+
+	switch {
+	case res.StatusCode == http.StatusUnauthorized:
+		if se := scopeError(res, c.endpointPath(req.URL), ae.Message); se != nil {
+			return CheckResult{Status: CheckWarn, Detail: se.Error(), Fix: "request the " + se.Scope + " scope for this key"}, res.Header
+		}
+		return CheckResult{Status: CheckFail, Detail: "API key rejected: " + res.Status, Fix: "configure a valid API key"}, res.Header
+	case res.StatusCode == http.StatusForbidden:
+		scope := ScopeAnalytics
+		if se := scopeError(res, c.endpointPath(req.URL), ae.Message); se != nil {
+			scope = se.Scope
+		}
+		return CheckResult{Status: CheckWarn, Detail: "API key accepted but lacks scope " + strconv.Quote(scope), Fix: "request the " + scope + " scope for this key"}, res.Header
+	case res.StatusCode == http.StatusTooManyRequests:
+		return CheckResult{Status: CheckWarn, Detail: "API key accepted but rate limited", Fix: "wait for the rate limit window to reset"}, res.Header
+	case res.StatusCode >= 500:
+		return CheckResult{Status: CheckWarn, Detail: "could not verify the key: " + res.Status, Fix: "retry later"}, res.Header
+	}
+	return CheckResult{Status: CheckPass, Detail: "API key accepted"}, res.Header
+}
+
+func checkQuota(ctx context.Context, h http.Header, minRemaining int) CheckResult {
+	if v := firstHeader(h, "X-RateLimit-Remaining", "RateLimit-Remaining"); v != "" {
+		remaining, err := strconv.Atoi(v)
+		if err != nil {
+			return CheckResult{Status: CheckSkip, Detail: "unparsable rate limit header " + strconv.Quote(v)}
+		}
+		detail := fmt.Sprintf("%d requests left in the current window", remaining)
+		if limit := firstHeader(h, "X-RateLimit-Limit", "RateLimit-Limit"); limit != "" {
+			detail = fmt.Sprintf("%d of %s requests left in the current window", remaining, limit)
+		}
+		if remaining < minRemaining {
+			return CheckResult{Status: CheckWarn, Detail: detail, Fix: "wait for the rate limit window to reset before launching"}
+		}
+		return CheckResult{Status: CheckPass, Detail: detail}
+	}
+	if n, ok := CallBudgetRemaining(ctx); ok {
+		if n < minRemaining {
+			return CheckResult{Status: CheckWarn, Detail: fmt.Sprintf("%d calls left in the context call budget", n), Fix: "raise the budget passed to WithCallBudget"}
+		}
+		return CheckResult{Status: CheckPass, Detail: fmt.Sprintf("%d calls left in the context call budget", n)}
+	}
+	return CheckResult{Status: CheckSkip, Detail: "the API reported no rate limit headers"}
+}
+
+func (c *BagsClient) checkRPC(ctx context.Context, rpcURL string) CheckResult {
+	if rpcURL == "" {
+		return CheckResult{Status: CheckSkip, Detail: "no RPC URL configured"}
+	}
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"getHealth"}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rpcURL, bytes.NewReader(body))
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: err.Error(), Fix: "check the RPC URL"}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	hc := c.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: err.Error(), Fix: "check network access to the RPC node"}
+	}
+	defer res.Body.Close()
+	var out struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&out); err != nil {
+		return CheckResult{Status: CheckFail, Detail: fmt.Sprintf("getHealth returned %s: %v", res.Status, err), Fix: "check the URL is a Solana JSON-RPC endpoint"}
+	}
+	if out.Error != nil {
+		return CheckResult{Status: CheckFail, Detail: "node unhealthy: " + out.Error.Message, Fix: "use another RPC node or wait for it to catch up"}
+	}
+	return CheckResult{Status: CheckPass, Detail: "getHealth: " + out.Result}
+}
+
+func (c *BagsClient) checkSigner() CheckResult {
+	if c.Signer == nil {
+		return CheckResult{Status: CheckSkip, Detail: "no signer configured"}
+	}
+	pk := c.Signer.PublicKey()
+	msg := []byte("bags self-test " + time.Now().UTC().Format(time.RFC3339Nano))
+	sig, err := c.Signer.SignMessage(msg)
+	if err != nil {
+		return CheckResult{Status: CheckFail, Detail: "signer " + pk.String() + ": " + err.Error(), Fix: "unlock or reconnect the signing wallet"}
+	}
+	if !ed25519.Verify(ed25519.PublicKey(pk[:]), msg, sig) {
+		return CheckResult{Status: CheckFail, Detail: "signer " + pk.String() + " produced an invalid signature", Fix: "check the signer's key matches its public key"}
+	}
+	return CheckResult{Status: CheckPass, Detail: "signer " + pk.String() + " can sign"}
+}
@@ -0,0 +1,78 @@
+// signer.go
+package bags
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// -------------------- Signer --------------------
+
+// Signer is the wallet a client launches tokens and pays fees with. It may
+// be backed by a local keypair, a hardware wallet, or a remote signing
+// service.
+type Signer interface {
+	PublicKey() PublicKey
+	// SignMessage returns the ed25519 signature of msg.
+	SignMessage(msg []byte) ([]byte, error)
+}
+
+// KeypairSigner is a Signer backed by an in-memory ed25519 key, e.g. one
+// loaded with ReadKeypairFile.
+type KeypairSigner ed25519.PrivateKey
+
+func (k KeypairSigner) PublicKey() PublicKey {
+	return PublicKeyFromEd25519(ed25519.PrivateKey(k).Public().(ed25519.PublicKey))
+}
+
+func (k KeypairSigner) SignMessage(msg []byte) ([]byte, error) {
+	if len(k) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("invalid keypair length %d", len(k))
+	}
+	return ed25519.Sign(ed25519.PrivateKey(k), msg), nil
+}
+
+// SignWith signs the message with each signer and stores the signature in
+// the slot of the matching required signer, like PartialSign. It does not
+// run the client's PreSignHook.
+func (tx *Transaction) SignWith(signers ...Signer) error {
+	msg, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	required := tx.RequiredSigners()
+	if len(tx.Signatures) < len(required) {
+		sigs := make([]Signature, len(required))
+		copy(sigs, tx.Signatures)
+		tx.Signatures = sigs
+	}
+	for _, s := range signers {
+		pk := s.PublicKey()
+		idx := slices.Index(required, pk)
+		if idx < 0 {
+			return fmt.Errorf("%s is not a required signer", pk)
+		}
+		sig, err := s.SignMessage(msg)
+		if err != nil {
+			return fmt.Errorf("sign with %s: %w", pk, err)
+		}
+		if len(sig) != ed25519.SignatureSize {
+			return fmt.Errorf("sign with %s: invalid signature length %d", pk, len(sig))
+		}
+		copy(tx.Signatures[idx][:], sig)
+	}
+	return nil
+}
+
+// ------- Internal Helpers -------
+
+// signerWallet returns wallet, or the Signer's address when wallet is empty
+// and a Signer is configured.
+func (c *BagsClient) signerWallet(wallet string) string {
+	if strings.TrimSpace(wallet) != "" || c.Signer == nil {
+		return wallet
+	}
+	return c.Signer.PublicKey().String()
+}
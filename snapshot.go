@@ -0,0 +1,179 @@
+// snapshot.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Snapshot field names used as keys in TokenSnapshot.Errors.
+const (
+	SnapshotFieldLifetimeFees = "lifetimeFees"
+	SnapshotFieldCreators     = "creators"
+)
+
+// -------------------- Token Snapshots --------------------
+
+// TokenSnapshot combines the analytics reads for a single mint.
+//
+// When a snapshot is returned with partial results, fields whose sub-endpoint
+// failed are left at their zero value and the failure is recorded in Errors
+// under the field name.
+type TokenSnapshot struct {
+	Mint         string
+	LifetimeFees Lamports
+	Creators     []TokenCreator
+	Errors       map[string]error
+}
+
+// Partial reports whether any field failed to load.
+func (s *TokenSnapshot) Partial() bool { return len(s.Errors) > 0 }
+
+// Err returns the combined field errors, or nil when every field loaded.
+func (s *TokenSnapshot) Err() error {
+	if len(s.Errors) == 0 {
+		return nil
+	}
+	fields := make([]string, 0, len(s.Errors))
+	for f := range s.Errors {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	errs := make([]error, len(fields))
+	for i, f := range fields {
+		errs[i] = fmt.Errorf("%s: %w", f, s.Errors[f])
+	}
+	return errors.Join(errs...)
+}
+
+// SnapshotOptions configures SnapshotToken and SnapshotTokens.
+type SnapshotOptions struct {
+	BatchOptions
+
+	// AllowPartial returns snapshots with per-field error annotations when
+	// some sub-endpoints fail, instead of failing the whole snapshot. A
+	// snapshot still fails when every field fails.
+	AllowPartial bool
+}
+
+// SnapshotToken loads lifetime fees and launch creators for mint concurrently.
+func (c *BagsClient) SnapshotToken(ctx context.Context, mint string, opts SnapshotOptions) (*TokenSnapshot, error) {
+	mint = strings.TrimSpace(mint)
+	if mint == "" {
+		return nil, fmt.Errorf("tokenMint is required")
+	}
+	s := &TokenSnapshot{Mint: mint}
+
+	var (
+		wg      sync.WaitGroup
+		feesErr error
+		credErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.LifetimeFees, feesErr = c.GetTokenLifetimeFeesLamports(ctx, mint)
+	}()
+	go func() {
+		defer wg.Done()
+		s.Creators, credErr = c.GetTokenLaunchCreators(ctx, mint)
+	}()
+	wg.Wait()
+
+	if feesErr != nil || credErr != nil {
+		s.Errors = make(map[string]error, 2)
+		if feesErr != nil {
+			s.Errors[SnapshotFieldLifetimeFees] = feesErr
+		}
+		if credErr != nil {
+			s.Errors[SnapshotFieldCreators] = credErr
+		}
+	}
+	if !opts.AllowPartial || len(s.Errors) == 2 {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// SnapshotTokens loads snapshots for many mints with bounded concurrency.
+// Results and errors follow the GetTokenLifetimeFeesBatch conventions; with
+// AllowPartial, partially loaded snapshots are reported in Results.
+func (c *BagsClient) SnapshotTokens(ctx context.Context, mints []string, opts SnapshotOptions) (*BatchResult[*TokenSnapshot], error) {
+	return runBatch(ctx, mints, opts.BatchOptions, func(ctx context.Context, mint string) (*TokenSnapshot, error) {
+		return c.SnapshotToken(ctx, mint, opts)
+	})
+}
+
+// -------------------- Creators With Fees --------------------
+
+// CreatorFees is a creator paired with their share of a mint's lifetime fees.
+type CreatorFees struct {
+	TokenCreator
+	Fees Lamports `json:"fees"`
+}
+
+// CreatorsWithFees is a mint's creators with the lifetime fees split between
+// them by RoyaltyBps, computed from a single pair of reads.
+type CreatorsWithFees struct {
+	Mint         string        `json:"mint"`
+	LifetimeFees Lamports      `json:"lifetimeFees"`
+	Creators     []CreatorFees `json:"creators"`
+	FetchedAt    time.Time     `json:"fetchedAt"`
+}
+
+// GetCreatorsWithFees fetches the creators and lifetime fees of mint
+// concurrently and computes each creator's share as LifetimeFees *
+// RoyaltyBps / TotalBps. When the royalties sum to TotalBps, the lamports
+// lost to rounding go to the creators with the largest remainders, so the
+// shares add up to LifetimeFees exactly. It fails if either read fails.
+func (c *BagsClient) GetCreatorsWithFees(ctx context.Context, mint string) (*CreatorsWithFees, error) {
+	s, err := c.SnapshotToken(ctx, mint, SnapshotOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &CreatorsWithFees{
+		Mint:         s.Mint,
+		LifetimeFees: s.LifetimeFees,
+		Creators:     splitCreatorFees(s.LifetimeFees, s.Creators),
+		FetchedAt:    time.Now(),
+	}, nil
+}
+
+// ------- Internal Helpers -------
+
+func splitCreatorFees(total Lamports, creators []TokenCreator) []CreatorFees {
+	out := make([]CreatorFees, len(creators))
+	rems := make([]uint64, len(creators))
+	var sumBps int64
+	var assigned Lamports
+	for i, cr := range creators {
+		out[i].TokenCreator = cr
+		bps := min(max(int64(cr.RoyaltyBps), 0), TotalBps)
+		sumBps += bps
+		hi, lo := bits.Mul64(uint64(total), uint64(bps))
+		q, r := bits.Div64(hi, lo, uint64(TotalBps))
+		out[i].Fees = Lamports(q)
+		rems[i] = r
+		assigned += out[i].Fees
+	}
+	if sumBps != TotalBps {
+		return out
+	}
+	order := make([]int, len(out))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return rems[order[a]] > rems[order[b]] })
+	for _, i := range order[:total-assigned] {
+		out[i].Fees++
+	}
+	return out
+}
@@ -0,0 +1,133 @@
+// statestore.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrStateNotFound is returned by StateStore.Load when a key has no value.
+var ErrStateNotFound = errors.New("state not found")
+
+// -------------------- State Stores --------------------
+
+// StateStore persists small pieces of SDK state, such as stream resume
+// cursors, across reconnects and process restarts. Implementations must be
+// safe for concurrent use.
+type StateStore interface {
+	// Load returns the value for key, or ErrStateNotFound.
+	Load(ctx context.Context, key string) ([]byte, error)
+	// Save stores value under key, replacing any previous value.
+	Save(ctx context.Context, key string, value []byte) error
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStateStore keeps state in process memory. It survives reconnects but
+// not restarts.
+type MemoryStateStore struct {
+	mu sync.Mutex
+	m  map[string][]byte
+}
+
+// NewMemoryStateStore returns an empty in-memory store.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{m: make(map[string][]byte)}
+}
+
+func (s *MemoryStateStore) Load(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.m[key]
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+	return append([]byte(nil), v...), nil
+}
+
+func (s *MemoryStateStore) Save(_ context.Context, key string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.m == nil {
+		s.m = make(map[string][]byte)
+	}
+	s.m[key] = append([]byte(nil), value...)
+	return nil
+}
+
+func (s *MemoryStateStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.m, key)
+	return nil
+}
+
+// FileStateStore keeps one file per key under Dir. Writes go to a temporary
+// file that is renamed into place, so a crash never leaves a torn value.
+type FileStateStore struct {
+	Dir string
+}
+
+// NewFileStateStore creates dir if needed and returns a store rooted there.
+func NewFileStateStore(dir string) (*FileStateStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStateStore{Dir: dir}, nil
+}
+
+func (s *FileStateStore) Load(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrStateNotFound
+	}
+	return data, err
+}
+
+func (s *FileStateStore) Save(_ context.Context, key string, value []byte) error {
+	f, err := os.CreateTemp(s.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(value)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("save state %q: %w", key, err)
+	}
+	return nil
+}
+
+func (s *FileStateStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path maps key to a single file name, escaping separators so keys like
+// "stream/trades/<mint>" stay inside Dir. A leading dot is escaped too, which
+// keeps "." and ".." in Dir and avoids clashing with temporary files.
+func (s *FileStateStore) path(key string) string {
+	name := url.PathEscape(key)
+	if name == "" || strings.HasPrefix(name, ".") {
+		name = "%2E" + strings.TrimPrefix(name, ".")
+	}
+	return filepath.Join(s.Dir, name)
+}
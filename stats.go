@@ -0,0 +1,71 @@
+// stats.go
+package bags
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// EndpointStats counts responses for one endpoint by status class. Each HTTP
+// attempt is counted, so retried requests appear once per attempt.
+type EndpointStats struct {
+	Status1xx uint64 `json:"1xx"`
+	Status2xx uint64 `json:"2xx"`
+	Status3xx uint64 `json:"3xx"`
+	Status4xx uint64 `json:"4xx"`
+	Status5xx uint64 `json:"5xx"`
+	// Errors counts attempts that produced no response (network errors,
+	// timeouts, cancellations).
+	Errors uint64 `json:"errors"`
+}
+
+// Total returns the number of attempts counted.
+func (s EndpointStats) Total() uint64 {
+	return s.Status1xx + s.Status2xx + s.Status3xx + s.Status4xx + s.Status5xx + s.Errors
+}
+
+// Stats returns a snapshot of response counts keyed by endpoint path
+// (relative to the base URL, e.g. "token-launch/creator/v2").
+func (c *BagsClient) Stats() map[string]EndpointStats {
+	out := make(map[string]EndpointStats)
+	c.stats.Range(func(k, v any) bool {
+		ctr := v.(*endpointCounters)
+		out[k.(string)] = EndpointStats{
+			Status1xx: ctr[0].Load(),
+			Status2xx: ctr[1].Load(),
+			Status3xx: ctr[2].Load(),
+			Status4xx: ctr[3].Load(),
+			Status5xx: ctr[4].Load(),
+			Errors:    ctr[5].Load(),
+		}
+		return true
+	})
+	return out
+}
+
+// ResetStats clears all counters.
+func (c *BagsClient) ResetStats() {
+	c.stats.Clear()
+}
+
+// endpointCounters holds one counter per status class plus transport errors.
+type endpointCounters [6]atomic.Uint64
+
+// recordStat counts one attempt against req's endpoint.
+func (c *BagsClient) recordStat(req *http.Request, res *http.Response) {
+	key := c.endpointPath(req.URL)
+	v, ok := c.stats.Load(key)
+	if !ok {
+		v, _ = c.stats.LoadOrStore(key, new(endpointCounters))
+	}
+	ctr := v.(*endpointCounters)
+	if res == nil {
+		ctr[5].Add(1)
+		return
+	}
+	class := res.StatusCode/100 - 1
+	if class < 0 || class > 4 {
+		class = 5
+	}
+	ctr[class].Add(1)
+}
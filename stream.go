@@ -0,0 +1,152 @@
+// stream.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// -------------------- Resumable Streams --------------------
+
+// StreamSource yields batches of events after a resume cursor. An empty
+// cursor means "from now" (or the source's earliest retained event).
+type StreamSource[T any] interface {
+	// Next blocks until at least one event after cursor is available, then
+	// returns the events and the cursor that resumes after the last of them.
+	Next(ctx context.Context, cursor string) (events []T, next string, err error)
+}
+
+// StreamSourceFunc adapts a function to a StreamSource.
+type StreamSourceFunc[T any] func(ctx context.Context, cursor string) ([]T, string, error)
+
+func (f StreamSourceFunc[T]) Next(ctx context.Context, cursor string) ([]T, string, error) {
+	return f(ctx, cursor)
+}
+
+// StreamOptions configures RunStream.
+type StreamOptions struct {
+	// Store persists the resume cursor. Nil keeps it in memory, so
+	// reconnects resume but a restarted process starts from now.
+	Store StateStore
+	// Key names the subscription in Store and is required when Store is set.
+	// Use a distinct key per consumer, e.g. "stream/trades/<mint>/alerts".
+	Key string
+	// Cursor is the starting cursor when Store has none saved.
+	Cursor string
+	// MinBackoff and MaxBackoff bound the reconnect delay after a source
+	// error. Zero means 500ms and 30s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// OnError is called for each source error before reconnecting.
+	OnError func(error)
+}
+
+// RunStream delivers events from src to handle until ctx is done or handle
+// returns an error.
+//
+// The cursor is saved after every batch has been handled, so delivery is
+// at-least-once: after a crash or handler error, events from the unsaved
+// batch are delivered again. Source errors are retried with backoff and the
+// stream resumes from the last saved cursor. RunStream returns ctx.Err() when
+// ctx is done, or the handler's error.
+func RunStream[T any](ctx context.Context, src StreamSource[T], opts StreamOptions, handle func(context.Context, T) error) error {
+	if src == nil || handle == nil {
+		return fmt.Errorf("stream source and handler are required")
+	}
+	if opts.Store != nil && opts.Key == "" {
+		return fmt.Errorf("stream key is required with a state store")
+	}
+	minBackoff, maxBackoff := opts.MinBackoff, opts.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = 500 * time.Millisecond
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+	policy := RetryPolicy{BaseDelay: minBackoff, MaxDelay: maxBackoff}
+
+	cursor := opts.Cursor
+	if opts.Store != nil {
+		saved, err := opts.Store.Load(ctx, opts.Key)
+		switch {
+		case err == nil:
+			cursor = string(saved)
+		case !errors.Is(err, ErrStateNotFound):
+			return fmt.Errorf("load stream cursor: %w", err)
+		}
+	}
+
+	failures := 0
+	for {
+		events, next, err := src.Next(ctx, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if opts.OnError != nil {
+				opts.OnError(err)
+			}
+			failures++
+			if err := sleepCtx(ctx, policy.backoff(min(failures, 16))); err != nil {
+				return err
+			}
+			continue
+		}
+		failures = 0
+		for _, ev := range events {
+			if err := handle(ctx, ev); err != nil {
+				return err
+			}
+		}
+		if next == "" || next == cursor {
+			continue
+		}
+		cursor = next
+		if opts.Store != nil {
+			if err := opts.Store.Save(ctx, opts.Key, []byte(cursor)); err != nil {
+				return fmt.Errorf("save stream cursor: %w", err)
+			}
+		}
+	}
+}
+
+// Subscription delivers stream events on C. C is closed when the stream
+// stops, after which Err reports why.
+type Subscription[T any] struct {
+	C    <-chan T
+	done chan struct{}
+	err  error
+}
+
+// Err returns the error that stopped the stream: ctx.Err() after the
+// subscription's context ends, or a cursor store failure. It blocks until C
+// is closed.
+func (s *Subscription[T]) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Subscribe runs src in the background like RunStream and delivers events on
+// the returned subscription's channel. A cursor is saved once every event of
+// its batch has been received from C, so events still being processed when
+// the process exits are not redelivered; use RunStream when handling must
+// complete before the cursor advances.
+func Subscribe[T any](ctx context.Context, src StreamSource[T], opts StreamOptions) *Subscription[T] {
+	ch := make(chan T)
+	s := &Subscription[T]{C: ch, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		defer close(ch)
+		s.err = RunStream(ctx, src, opts, func(ctx context.Context, ev T) error {
+			select {
+			case ch <- ev:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return s
+}
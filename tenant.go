@@ -0,0 +1,214 @@
+// tenant.go
+package bags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrTenantSpendExceeded is wrapped by errors from calls that would take a
+// tenant past its SpendLimit.
+var ErrTenantSpendExceeded = errors.New("tenant spend limit exceeded")
+
+// DefaultSpendWindow is the spend accounting window used when
+// TenantQuota.SpendWindow is zero.
+const DefaultSpendWindow = 24 * time.Hour
+
+type tenantKey struct{}
+
+// -------------------- Tenants --------------------
+
+// WithTenant tags every API call made with ctx as belonging to tenant, so a
+// shared client can enforce per-tenant quotas through BagsClient.Tenants.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFrom returns the tenant ctx was tagged with, or "".
+func TenantFrom(ctx context.Context) string {
+	t, _ := ctx.Value(tenantKey{}).(string)
+	return t
+}
+
+// TenantQuota limits one tenant's use of a shared client.
+type TenantQuota struct {
+	// Rate is the sustained number of HTTP attempts per second; calls over
+	// the rate wait their turn. Zero means unlimited.
+	Rate float64
+	// Burst is the number of attempts allowed at once. Zero means
+	// max(1, ceil(Rate)).
+	Burst int
+	// SpendLimit caps the SOL a tenant commits through launch initial buys
+	// within SpendWindow. Zero means unlimited.
+	SpendLimit Lamports
+	// SpendWindow is the sliding window for SpendLimit. Zero means
+	// DefaultSpendWindow.
+	SpendWindow time.Duration
+}
+
+// TenantUsage is a snapshot of one tenant's consumption.
+type TenantUsage struct {
+	Tenant string   `json:"tenant"`
+	Spent  Lamports `json:"spent"`  // within the current spend window
+	Tokens float64  `json:"tokens"` // rate limiter tokens available now; negative while calls queue
+}
+
+// TenantLimiter enforces TenantQuotas on calls tagged with WithTenant.
+// Untagged calls are not limited. It is safe for concurrent use.
+type TenantLimiter struct {
+	mu      sync.Mutex
+	def     TenantQuota
+	quotas  map[string]TenantQuota
+	tenants map[string]*tenantState
+}
+
+type tenantState struct {
+	tokens float64
+	last   time.Time
+	spends []tenantSpend
+}
+
+type tenantSpend struct {
+	at     time.Time
+	amount Lamports
+}
+
+// NewTenantLimiter returns a limiter applying def to every tenant without a
+// quota of its own.
+func NewTenantLimiter(def TenantQuota) *TenantLimiter {
+	return &TenantLimiter{def: def, quotas: make(map[string]TenantQuota), tenants: make(map[string]*tenantState)}
+}
+
+// SetQuota sets tenant's quota, replacing the default for it.
+func (l *TenantLimiter) SetQuota(tenant string, q TenantQuota) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.quotas[tenant] = q
+}
+
+// Usage reports tenant's current consumption.
+func (l *TenantLimiter) Usage(tenant string) TenantUsage {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	q := l.quota(tenant)
+	st := l.stateLocked(tenant, q, now)
+	return TenantUsage{Tenant: tenant, Spent: st.spent(q, now), Tokens: st.tokens}
+}
+
+// ------- Internal Helpers -------
+
+func (l *TenantLimiter) quota(tenant string) TenantQuota {
+	if q, ok := l.quotas[tenant]; ok {
+		return q
+	}
+	return l.def
+}
+
+func (q TenantQuota) burst() float64 {
+	if q.Burst > 0 {
+		return float64(q.Burst)
+	}
+	return math.Max(1, math.Ceil(q.Rate))
+}
+
+func (q TenantQuota) window() time.Duration {
+	if q.SpendWindow > 0 {
+		return q.SpendWindow
+	}
+	return DefaultSpendWindow
+}
+
+// stateLocked returns tenant's state with its token bucket refilled to now.
+func (l *TenantLimiter) stateLocked(tenant string, q TenantQuota, now time.Time) *tenantState {
+	st, ok := l.tenants[tenant]
+	if !ok {
+		st = &tenantState{tokens: q.burst(), last: now}
+		l.tenants[tenant] = st
+	}
+	if q.Rate > 0 {
+		st.tokens = math.Min(q.burst(), st.tokens+now.Sub(st.last).Seconds()*q.Rate)
+	}
+	st.last = now
+	return st
+}
+
+// spent drops spends outside the window and sums the rest.
+func (st *tenantState) spent(q TenantQuota, now time.Time) Lamports {
+	cutoff := now.Add(-q.window())
+	i := 0
+	for i < len(st.spends) && !st.spends[i].at.After(cutoff) {
+		i++
+	}
+	st.spends = st.spends[i:]
+	var total Lamports
+	for _, s := range st.spends {
+		total += s.amount
+	}
+	return total
+}
+
+// wait takes one rate limiter token for ctx's tenant, waiting until one is
+// available. A nil limiter or an untagged ctx never waits.
+func (l *TenantLimiter) wait(ctx context.Context) error {
+	tenant := TenantFrom(ctx)
+	if l == nil || tenant == "" {
+		return nil
+	}
+	l.mu.Lock()
+	q := l.quota(tenant)
+	if q.Rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	st := l.stateLocked(tenant, q, time.Now())
+	st.tokens--
+	delay := time.Duration(-st.tokens / q.Rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if err := sleepCtx(ctx, delay); err != nil {
+		l.mu.Lock()
+		st.tokens++ // give the reserved token back
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// reserveSpend records amount against ctx's tenant, failing when it would
+// exceed the tenant's SpendLimit. The returned func refunds the reservation
+// and must be called if the spend does not happen.
+func (l *TenantLimiter) reserveSpend(ctx context.Context, amount Lamports) (func(), error) {
+	tenant := TenantFrom(ctx)
+	if l == nil || tenant == "" || amount == 0 {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q := l.quota(tenant)
+	if q.SpendLimit == 0 {
+		return func() {}, nil
+	}
+	now := time.Now()
+	st := l.stateLocked(tenant, q, now)
+	if spent := st.spent(q, now); spent+amount > q.SpendLimit {
+		return nil, fmt.Errorf("tenant %q: spending %s SOL on top of %s SOL exceeds %s SOL per %s: %w",
+			tenant, amount.SOLString(), spent.SOLString(), q.SpendLimit.SOLString(), q.window(), ErrTenantSpendExceeded)
+	}
+	entry := tenantSpend{at: now, amount: amount}
+	st.spends = append(st.spends, entry)
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, s := range st.spends {
+			if s == entry {
+				st.spends = append(st.spends[:i], st.spends[i+1:]...)
+				return
+			}
+		}
+	}, nil
+}
@@ -0,0 +1,184 @@
+// throttle.go
+package bags
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// -------------------- Adaptive Throttling --------------------
+
+// ThrottlePolicy configures client-wide adaptive throttling: when too many
+// attempts fail (network errors, 429, 500, 502, 503, 504), the client paces
+// its requests at a reduced rate and speeds back up as the API recovers,
+// instead of hammering an unstable API into a temporary ban.
+//
+// The zero value disables throttling.
+type ThrottlePolicy struct {
+	// ErrorThreshold is the failed fraction of attempts within Window, in
+	// (0, 1), above which the rate is halved, at most once per second. Zero
+	// disables throttling.
+	ErrorThreshold float64
+	// Window is the sliding window the error ratio is measured over. Zero
+	// means one minute.
+	Window time.Duration
+	// MinSamples is the number of attempts Window must hold before the
+	// ratio is acted on. Zero means 20.
+	MinSamples int
+	// MinRate is the floor of the reduced rate in attempts per second. Zero
+	// means 1.
+	MinRate float64
+}
+
+// ThrottleStatus is a snapshot of the adaptive throttle.
+type ThrottleStatus struct {
+	Throttled  bool    `json:"throttled"`
+	Rate       float64 `json:"rate,omitempty"` // attempts per second while throttled
+	ErrorRatio float64 `json:"errorRatio"`     // within the window
+	Attempts   int     `json:"attempts"`       // within the window
+}
+
+// ThrottleStatus reports the current state of the adaptive throttle.
+func (c *BagsClient) ThrottleStatus() ThrottleStatus {
+	t := &c.throttle
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	total, failed := t.countsLocked(c.Config().Throttle, time.Now())
+	st := ThrottleStatus{Throttled: t.rate > 0, Rate: t.rate, Attempts: total}
+	if total > 0 {
+		st.ErrorRatio = float64(failed) / float64(total)
+	}
+	return st
+}
+
+// ------- Internal Helpers -------
+
+type throttleState struct {
+	mu      sync.Mutex
+	buckets []throttleBucket // one per second of the window, indexed by unix second
+	rate    float64          // zero while not throttled
+	peak    float64          // attempt rate when throttling started
+	next    time.Time        // earliest start of the next paced attempt
+	checked time.Time        // last rate adjustment
+}
+
+type throttleBucket struct {
+	sec           int64
+	total, failed int
+}
+
+func (p ThrottlePolicy) enabled() bool { return p.ErrorThreshold > 0 }
+
+func (p ThrottlePolicy) window() time.Duration {
+	if p.Window <= 0 {
+		return time.Minute
+	}
+	return p.Window
+}
+
+func (p ThrottlePolicy) minSamples() int {
+	if p.MinSamples <= 0 {
+		return 20
+	}
+	return p.MinSamples
+}
+
+func (p ThrottlePolicy) minRate() float64 {
+	if p.MinRate <= 0 {
+		return 1
+	}
+	return p.MinRate
+}
+
+// countsLocked sums the attempts within the window ending at now.
+func (t *throttleState) countsLocked(p ThrottlePolicy, now time.Time) (total, failed int) {
+	total, failed, _ = t.spanLocked(p, now)
+	return total, failed
+}
+
+// spanLocked is countsLocked that also returns the seconds between the
+// oldest counted attempt and now, for the observed attempt rate.
+func (t *throttleState) spanLocked(p ThrottlePolicy, now time.Time) (total, failed int, secs float64) {
+	cutoff, first := now.Add(-p.window()).Unix(), now.Unix()
+	for _, b := range t.buckets {
+		if b.sec > cutoff {
+			total += b.total
+			failed += b.failed
+			first = min(first, b.sec)
+		}
+	}
+	return total, failed, float64(now.Unix() - first + 1)
+}
+
+// throttleWait paces the attempt when the client is throttled.
+func (c *BagsClient) throttleWait(ctx context.Context, p ThrottlePolicy) error {
+	if !p.enabled() {
+		return nil
+	}
+	t := &c.throttle
+	t.mu.Lock()
+	if t.rate <= 0 {
+		t.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(time.Duration(float64(time.Second) / t.rate))
+	t.mu.Unlock()
+	return sleepCtx(ctx, start.Sub(now))
+}
+
+// throttleRecord counts an attempt's outcome and adjusts the rate at most
+// once per second: halved while the error ratio is above the threshold,
+// raised by a tenth of the pre-throttle rate (at least MinRate) while it is
+// below, and lifted entirely once back at that rate.
+func (c *BagsClient) throttleRecord(p ThrottlePolicy, failed bool) {
+	if !p.enabled() {
+		return
+	}
+	t := &c.throttle
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	n := int(math.Ceil(p.window().Seconds()))
+	if len(t.buckets) != n {
+		t.buckets = make([]throttleBucket, n)
+	}
+	sec := now.Unix()
+	b := &t.buckets[sec%int64(n)]
+	if b.sec != sec {
+		*b = throttleBucket{sec: sec}
+	}
+	b.total++
+	if failed {
+		b.failed++
+	}
+
+	if now.Sub(t.checked) < time.Second {
+		return
+	}
+	total, fails, secs := t.spanLocked(p, now)
+	if total < p.minSamples() {
+		return
+	}
+	t.checked = now
+	if float64(fails)/float64(total) > p.ErrorThreshold {
+		if t.rate <= 0 {
+			t.peak = float64(total) / secs
+			t.rate = t.peak
+		}
+		t.rate = math.Max(p.minRate(), t.rate/2)
+		return
+	}
+	if t.rate > 0 {
+		t.rate += math.Max(t.peak/10, p.minRate())
+		if t.rate >= t.peak {
+			t.rate, t.next = 0, time.Time{}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package bags
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -26,10 +27,15 @@ type CreateTokenInfoRequest struct {
 	Twitter     string
 	Website     string
 
-	// Image is required; filename is sent in Content-Disposition.
+	// Image is required unless ImageURL is set; filename is sent in Content-Disposition.
 	Image         io.Reader
 	ImageFilename string
 	ImageMIMEType string // optional; defaults to application/octet-stream when empty
+
+	// ImageURL references an already-uploaded image and is sent as imageUrl
+	// when Image is nil. Large images are uploaded and referenced this way
+	// automatically when BagsClient.ImageUploader is set.
+	ImageURL string
 }
 
 type CreateTokenInfoResult struct {
@@ -50,18 +56,30 @@ type TokenLaunchObj struct {
 	TokenMint    string `json:"tokenMint"`
 	Status       string `json:"status"` // e.g., "PRE_LAUNCH"
 	LaunchWallet string `json:"launchWallet"`
-	LaunchSig    string `json:"launchSignature"`
+	LaunchSig    string `json:"launchSignature"` // see LaunchSignature
 	URI          string `json:"uri"`
 	CreatedAtISO string `json:"createdAt"`
 	UpdatedAtISO string `json:"updatedAt"`
 }
 
+// LaunchSignature parses LaunchSig. It returns a zero Signature before
+// launch, and an error when the API returned a malformed signature.
+func (t *TokenLaunchObj) LaunchSignature() (Signature, error) {
+	if t.LaunchSig == "" {
+		return Signature{}, nil
+	}
+	return ParseSignature(t.LaunchSig)
+}
+
 // CreateTokenLaunchConfigRequest/Result for config creation.
 // POST https://public-api-v2.bags.fm/api/v1/token-launch/create-config (application/json)
 // Auth header: x-api-key
 // Ref: https://bags.mintlify.app/api-reference/create-token-launch-configuration
 type CreateTokenLaunchConfigRequest struct {
 	LaunchWallet string `json:"launchWallet"`
+	// QuoteMint selects a quote mint other than wSOL on API versions that
+	// support it. Empty means wSOL.
+	QuoteMint string `json:"quoteMint,omitempty"`
 }
 type CreateTokenLaunchConfigResult struct {
 	Tx        string `json:"tx"`
@@ -73,11 +91,11 @@ type CreateTokenLaunchConfigResult struct {
 // Auth header: x-api-key
 // Ref: https://bags.mintlify.app/api-reference/create-token-launch-transaction
 type CreateTokenLaunchTxRequest struct {
-	IPFS               string `json:"ipfs"`
-	TokenMint          string `json:"tokenMint"`
-	Wallet             string `json:"wallet"`
-	InitialBuyLamports int64  `json:"initialBuyLamports"`
-	ConfigKey          string `json:"configKey"`
+	IPFS               string   `json:"ipfs"`
+	TokenMint          string   `json:"tokenMint"`
+	Wallet             string   `json:"wallet"`
+	InitialBuyLamports Lamports `json:"initialBuyLamports"`
+	ConfigKey          string   `json:"configKey"`
 }
 type CreateTokenLaunchTxResult struct {
 	Transaction string // "response" is a plain string (base64 tx)
@@ -86,6 +104,8 @@ type CreateTokenLaunchTxResult struct {
 // -------------------- Methods --------------------
 
 // CreateTokenInfoAndMetadata uploads metadata + image and returns created info.
+// With a LaunchGuard configured, it first takes the launch lock for the
+// symbol and fails with a *LaunchConflictError if another operator holds it.
 // Endpoint: POST token-launch/create-token-info (multipart/form-data)
 func (c *BagsClient) CreateTokenInfoAndMetadata(ctx context.Context, in *CreateTokenInfoRequest) (*CreateTokenInfoResult, error) {
 	if in == nil {
@@ -94,8 +114,31 @@ func (c *BagsClient) CreateTokenInfoAndMetadata(ctx context.Context, in *CreateT
 	if strings.TrimSpace(in.Name) == "" || strings.TrimSpace(in.Symbol) == "" {
 		return nil, fmt.Errorf("name and symbol are required")
 	}
-	if in.Image == nil || strings.TrimSpace(in.ImageFilename) == "" {
-		return nil, fmt.Errorf("image and image filename are required")
+	if in.Image == nil && strings.TrimSpace(in.ImageURL) == "" {
+		return nil, fmt.Errorf("image or image URL is required")
+	}
+	if in.Image != nil && strings.TrimSpace(in.ImageFilename) == "" {
+		return nil, fmt.Errorf("image filename is required")
+	}
+	if err := c.validators.run(in); err != nil {
+		return nil, err
+	}
+	if err := c.guardLaunch(ctx, in.Symbol); err != nil {
+		return nil, err
+	}
+	res, err := c.createTokenInfo(ctx, in)
+	if err != nil {
+		// Nothing was created, so don't block others from launching it.
+		_ = c.ReleaseLaunch(ctx, in.Symbol)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *BagsClient) createTokenInfo(ctx context.Context, in *CreateTokenInfoRequest) (*CreateTokenInfoResult, error) {
+	in, err := c.preUploadImage(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("pre-upload image: %w", err)
 	}
 
 	pr, pw := io.Pipe()
@@ -103,9 +146,6 @@ func (c *BagsClient) CreateTokenInfoAndMetadata(ctx context.Context, in *CreateT
 
 	// stream multipart body
 	go func() {
-		defer mw.Close()
-		defer pw.Close()
-
 		writeField := func(k, v string) error {
 			if strings.TrimSpace(v) == "" {
 				return nil
@@ -118,6 +158,10 @@ func (c *BagsClient) CreateTokenInfoAndMetadata(ctx context.Context, in *CreateT
 		_ = writeField("telegram", in.Telegram)
 		_ = writeField("twitter", in.Twitter)
 		_ = writeField("website", in.Website)
+		if in.Image == nil {
+			_ = pw.CloseWithError(errors.Join(writeField("imageUrl", in.ImageURL), mw.Close()))
+			return
+		}
 
 		ctype := in.ImageMIMEType
 		if strings.TrimSpace(ctype) == "" {
@@ -136,6 +180,9 @@ func (c *BagsClient) CreateTokenInfoAndMetadata(ctx context.Context, in *CreateT
 			_ = pw.CloseWithError(err)
 			return
 		}
+		// Close the multipart writer first so the closing boundary is
+		// written before the pipe is closed.
+		_ = pw.CloseWithError(mw.Close())
 	}()
 
 	// IMPORTANT: path is relative (no leading slash) to avoid clobbering BaseURL path.
@@ -158,16 +205,27 @@ func (c *BagsClient) CreateTokenInfoAndMetadata(ctx context.Context, in *CreateT
 }
 
 // CreateTokenLaunchConfig creates the config-creation transaction for a wallet.
+// An empty LaunchWallet defaults to the client's Signer. A non-wSOL QuoteMint
+// rejected by the API fails with a *QuoteMintError.
 // Endpoint: POST token-launch/create-config (application/json)
 func (c *BagsClient) CreateTokenLaunchConfig(ctx context.Context, in *CreateTokenLaunchConfigRequest) (*CreateTokenLaunchConfigResult, error) {
+	if in != nil {
+		req := *in
+		req.LaunchWallet = c.signerWallet(req.LaunchWallet)
+		in = &req
+	}
 	if in == nil || strings.TrimSpace(in.LaunchWallet) == "" {
 		return nil, fmt.Errorf("launchWallet is required")
 	}
+	if err := c.validators.run(in); err != nil {
+		return nil, err
+	}
 	var env struct {
 		Success  bool                           `json:"success"`
 		Response *CreateTokenLaunchConfigResult `json:"response"`
 	}
-	if err := c.postJSON(ctx, "token-launch/create-config", in, &env); err != nil {
+	err := c.postJSON(ctx, "token-launch/create-config", in, &env)
+	if err := c.observeQuoteMint("token-launch/create-config", in.QuoteMint, err); err != nil {
 		return nil, err
 	}
 	if !env.Success || env.Response == nil {
@@ -177,26 +235,40 @@ func (c *BagsClient) CreateTokenLaunchConfig(ctx context.Context, in *CreateToke
 }
 
 // CreateTokenLaunchTransaction builds the final launch transaction (signed with token mint).
+// An empty Wallet defaults to the client's Signer. With Tenants set, the
+// initial buy counts towards the tenant's SpendLimit.
 // Endpoint: POST token-launch/create-launch-transaction (application/json)
 func (c *BagsClient) CreateTokenLaunchTransaction(ctx context.Context, in *CreateTokenLaunchTxRequest) (*CreateTokenLaunchTxResult, error) {
 	if in == nil {
 		return nil, fmt.Errorf("nil request")
 	}
+	req := *in
+	req.Wallet = c.signerWallet(req.Wallet)
+	in = &req
 	if strings.TrimSpace(in.IPFS) == "" ||
 		strings.TrimSpace(in.TokenMint) == "" ||
 		strings.TrimSpace(in.Wallet) == "" ||
 		strings.TrimSpace(in.ConfigKey) == "" {
 		return nil, fmt.Errorf("ipfs, tokenMint, wallet, and configKey are required")
 	}
+	if err := c.validators.run(in); err != nil {
+		return nil, err
+	}
+	refund, err := c.Tenants.reserveSpend(ctx, in.InitialBuyLamports)
+	if err != nil {
+		return nil, err
+	}
 
 	var env struct {
 		Success  bool   `json:"success"`
 		Response string `json:"response"`
 	}
 	if err := c.postJSON(ctx, "token-launch/create-launch-transaction", in, &env); err != nil {
+		refund()
 		return nil, err
 	}
 	if !env.Success || strings.TrimSpace(env.Response) == "" {
+		refund()
 		return nil, fmt.Errorf("unexpected response")
 	}
 	return &CreateTokenLaunchTxResult{Transaction: env.Response}, nil
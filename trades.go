@@ -0,0 +1,77 @@
+// trades.go
+package bags
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// -------------------- Trade Streams --------------------
+
+// TradeSide is the direction of a trade from the trader's point of view.
+type TradeSide string
+
+const (
+	TradeBuy  TradeSide = "buy"
+	TradeSell TradeSide = "sell"
+)
+
+// Trade is one swap of a Bags token.
+type Trade struct {
+	Mint        string    `json:"mint"`
+	Side        TradeSide `json:"side"`
+	TokenAmount uint64    `json:"tokenAmount"` // in the token's base units
+	SOLAmount   Lamports  `json:"solAmount"`
+	PriceSOL    Decimal   `json:"priceSol"` // SOL per whole token, as reported by the feed
+	Wallet      string    `json:"wallet"`
+	Signature   Signature `json:"signature"`
+	Slot        uint64    `json:"slot"`
+	Time        time.Time `json:"time"`
+}
+
+// TradeFeed supplies trades for a token after a resume cursor. It has the
+// same contract as StreamSource: block until trades are available, then
+// return them with the cursor that follows the last one. Implementations
+// typically wrap an indexer, a websocket bridge, or a chain RPC poller.
+type TradeFeed interface {
+	Trades(ctx context.Context, mint, cursor string) ([]Trade, string, error)
+}
+
+// TradeFeedFunc adapts a function to a TradeFeed.
+type TradeFeedFunc func(ctx context.Context, mint, cursor string) ([]Trade, string, error)
+
+func (f TradeFeedFunc) Trades(ctx context.Context, mint, cursor string) ([]Trade, string, error) {
+	return f(ctx, mint, cursor)
+}
+
+// Streams groups the client's streaming subscriptions.
+type Streams struct {
+	c *BagsClient
+}
+
+// Streams returns the client's streaming subscriptions.
+func (c *BagsClient) Streams() *Streams { return &Streams{c: c} }
+
+// Trades subscribes to trades of mint from the client's TradeFeed.
+// Disconnects are retried with backoff and resume from the last cursor; with
+// opts.Store set, the cursor also survives restarts. opts.Key defaults to
+// "stream/trades/<mint>".
+func (s *Streams) Trades(ctx context.Context, mint string, opts StreamOptions) (*Subscription[Trade], error) {
+	mint = strings.TrimSpace(mint)
+	if mint == "" {
+		return nil, fmt.Errorf("mint is required")
+	}
+	feed := s.c.TradeFeed
+	if feed == nil {
+		return nil, fmt.Errorf("no trade feed configured")
+	}
+	if opts.Key == "" {
+		opts.Key = "stream/trades/" + mint
+	}
+	src := StreamSourceFunc[Trade](func(ctx context.Context, cursor string) ([]Trade, string, error) {
+		return feed.Trades(ctx, mint, cursor)
+	})
+	return Subscribe(ctx, src, opts), nil
+}
@@ -0,0 +1,302 @@
+// tweet.go
+package bags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// -------------------- Launch From Tweet --------------------
+
+// Tweet is the subset of tweet data needed to tokenize it.
+type Tweet struct {
+	ID             string `json:"id"`
+	URL            string `json:"url"`
+	AuthorUsername string `json:"authorUsername"` // without @
+	Text           string `json:"text"`
+	ImageURL       string `json:"imageUrl"` // first attached image
+}
+
+// TweetFetcher loads a tweet by URL. The SDK ships no implementation since
+// Twitter/X access requires its own credentials; plug in your own.
+type TweetFetcher interface {
+	FetchTweet(ctx context.Context, tweetURL string) (*Tweet, error)
+}
+
+// TweetFetcherFunc adapts a function to a TweetFetcher.
+type TweetFetcherFunc func(ctx context.Context, tweetURL string) (*Tweet, error)
+
+// FetchTweet calls f(ctx, tweetURL).
+func (f TweetFetcherFunc) FetchTweet(ctx context.Context, tweetURL string) (*Tweet, error) {
+	return f(ctx, tweetURL)
+}
+
+// HTTPTweetFetcher loads tweets from a service you run in front of the X
+// API: GET URL?url=<tweet URL> must return the Tweet as JSON.
+type HTTPTweetFetcher struct {
+	URL  string
+	HTTP *http.Client // defaults to http.DefaultClient
+}
+
+// FetchTweet fetches and decodes the tweet.
+func (h *HTTPTweetFetcher) FetchTweet(ctx context.Context, tweetURL string) (*Tweet, error) {
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parse tweet fetcher URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("url", tweetURL)
+	u.RawQuery = q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	hc := h.HTTP
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("tweet fetcher: %s", res.Status)
+	}
+	var tw Tweet
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&tw); err != nil {
+		return nil, fmt.Errorf("decode tweet: %w", err)
+	}
+	return &tw, nil
+}
+
+// LaunchFromTweetOptions configures LaunchFromTweet.
+type LaunchFromTweetOptions struct {
+	// Fetcher loads the tweet. Required.
+	Fetcher TweetFetcher
+	// Wallet launches the token and pays for the fee share config. Required
+	// unless the client has a Signer, which it then defaults to.
+	Wallet string
+	// Name and Symbol override the values derived from the tweet text.
+	Name   string
+	Symbol string
+	// Description overrides the tweet text as the token description.
+	Description string
+	// InitialBuyLamports is the initial buy placed by Wallet.
+	InitialBuyLamports Lamports
+	// AuthorBps is the share of fees routed to the tweet author's fee share
+	// wallet; the remainder goes to Wallet. Nil means TotalBps (all fees to
+	// the author); point it at zero to keep every fee.
+	AuthorBps *int64
+	// Image controls verification of the downloaded tweet image.
+	Image *ImageFetchOptions
+}
+
+// LaunchFromTweetResult holds every artifact produced by LaunchFromTweet.
+//
+// FeeShare.Tx (when non-empty) must be signed and confirmed before
+// Transaction is broadcast.
+type LaunchFromTweetResult struct {
+	Tweet        *Tweet
+	AuthorWallet string
+	TokenInfo    *CreateTokenInfoResult
+	FeeShare     *CreateFeeShareConfigResult
+	Transaction  *CreateTokenLaunchTxResult
+}
+
+// LaunchFromTweet runs the "tokenize this tweet" workflow: it fetches the
+// tweet, downloads its image, prefills token metadata from the text,
+// resolves the author's fee share wallet, creates the token info, creates a
+// fee share config between Wallet and the author, and builds the launch
+// transaction.
+//
+// The SDK does not sign or broadcast; the returned transactions are left to
+// the caller. With a LaunchGuard configured, the symbol's launch lock is
+// held on success, as with CreateTokenInfoAndMetadata: call ReleaseLaunch
+// after broadcasting, or let the lock expire.
+func (c *BagsClient) LaunchFromTweet(ctx context.Context, tweetURL string, opts *LaunchFromTweetOptions) (*LaunchFromTweetResult, error) {
+	if opts == nil || opts.Fetcher == nil {
+		return nil, fmt.Errorf("tweet fetcher is required")
+	}
+	wallet := c.signerWallet(opts.Wallet)
+	if strings.TrimSpace(wallet) == "" {
+		return nil, fmt.Errorf("wallet is required")
+	}
+	if strings.TrimSpace(tweetURL) == "" {
+		return nil, fmt.Errorf("tweet URL is required")
+	}
+	authorBps := TotalBps
+	if opts.AuthorBps != nil {
+		authorBps = *opts.AuthorBps
+	}
+	if authorBps < 0 || authorBps > TotalBps {
+		return nil, fmt.Errorf("authorBps must be between 0 and %d, got %d", TotalBps, authorBps)
+	}
+
+	tw, err := opts.Fetcher.FetchTweet(ctx, tweetURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch tweet: %w", err)
+	}
+	if tw == nil {
+		return nil, fmt.Errorf("fetch tweet: no tweet returned")
+	}
+	if tw.URL == "" {
+		tw.URL = tweetURL
+	}
+	if strings.TrimSpace(tw.AuthorUsername) == "" {
+		return nil, fmt.Errorf("tweet has no author")
+	}
+	if strings.TrimSpace(tw.ImageURL) == "" {
+		return nil, fmt.Errorf("tweet has no image")
+	}
+	out := &LaunchFromTweetResult{Tweet: tw}
+
+	img, err := c.FetchImage(ctx, tw.ImageURL, opts.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	out.AuthorWallet, err = c.feeShareWallet(ctx, strings.TrimPrefix(tw.AuthorUsername, "@"))
+	if err != nil {
+		return nil, fmt.Errorf("resolve author wallet: %w", err)
+	}
+
+	info := &CreateTokenInfoRequest{
+		Name:        firstNonEmpty(opts.Name, tweetTokenName(tw.Text)),
+		Symbol:      firstNonEmpty(opts.Symbol, tweetTokenSymbol(tw.Text)),
+		Description: firstNonEmpty(opts.Description, tw.Text),
+		Twitter:     tw.URL,
+	}
+	img.Apply(info)
+	out.TokenInfo, err = c.CreateTokenInfoAndMetadata(ctx, info)
+	if err != nil {
+		return nil, fmt.Errorf("create token info: %w", err)
+	}
+	// The launch lock stays held on success; only failures past this point
+	// hand the symbol back.
+	fail := func(err error) (*LaunchFromTweetResult, error) {
+		_ = c.ReleaseLaunch(context.WithoutCancel(ctx), info.Symbol)
+		return nil, err
+	}
+
+	out.FeeShare, err = c.CreateFeeShareConfig(ctx, &CreateFeeShareConfigRequest{
+		WalletA:    wallet,
+		WalletB:    out.AuthorWallet,
+		WalletABps: TotalBps - authorBps,
+		WalletBBps: authorBps,
+		Payer:      wallet,
+		BaseMint:   out.TokenInfo.TokenMint,
+		QuoteMint:  WrappedSOLMint,
+	})
+	if err != nil {
+		return fail(fmt.Errorf("create fee share config: %w", err))
+	}
+
+	out.Transaction, err = c.CreateTokenLaunchTransaction(ctx, &CreateTokenLaunchTxRequest{
+		IPFS:               out.TokenInfo.TokenMetadata,
+		TokenMint:          out.TokenInfo.TokenMint,
+		Wallet:             wallet,
+		InitialBuyLamports: opts.InitialBuyLamports,
+		ConfigKey:          out.FeeShare.ConfigKey,
+	})
+	if err != nil {
+		return fail(fmt.Errorf("create launch transaction: %w", err))
+	}
+	return out, nil
+}
+
+// ------- Internal Helpers -------
+
+const (
+	maxTweetNameLen   = 32
+	maxTweetSymbolLen = 10
+)
+
+var (
+	cashtagRe  = regexp.MustCompile(`\$([A-Za-z][A-Za-z0-9]{0,9})\b`)
+	tweetURLRe = regexp.MustCompile(`https?://\S+`)
+)
+
+// tweetTokenName derives a token name from tweet text: URLs and cashtags are
+// dropped and the result is truncated to maxTweetNameLen bytes on a word
+// boundary, or on a rune boundary when there is none.
+func tweetTokenName(text string) string {
+	text = tweetURLRe.ReplaceAllString(text, "")
+	text = cashtagRe.ReplaceAllString(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) <= maxTweetNameLen {
+		return text
+	}
+	n := 0
+	for n < len(text) {
+		_, size := utf8.DecodeRuneInString(text[n:])
+		if n+size > maxTweetNameLen {
+			break
+		}
+		n += size
+	}
+	cut := text[:n]
+	if i := strings.LastIndexByte(cut, ' '); i > 0 {
+		cut = cut[:i]
+	}
+	return cut
+}
+
+// tweetTokenSymbol uses the first cashtag in the text, falling back to the
+// initials of the first words.
+func tweetTokenSymbol(text string) string {
+	if m := cashtagRe.FindStringSubmatch(text); m != nil {
+		return strings.ToUpper(m[1])
+	}
+	var b strings.Builder
+	for _, w := range strings.Fields(tweetURLRe.ReplaceAllString(text, "")) {
+		for _, r := range w {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				b.WriteRune(unicode.ToUpper(r))
+				break
+			}
+		}
+		if b.Len() == maxTweetSymbolLen {
+			break
+		}
+	}
+	return b.String()
+}
+
+// TweetIDFromURL extracts the status ID from a twitter.com or x.com status URL.
+func TweetIDFromURL(tweetURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(tweetURL))
+	if err != nil {
+		return "", fmt.Errorf("parse tweet URL: %w", err)
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	host = strings.TrimPrefix(host, "mobile.")
+	if host != "twitter.com" && host != "x.com" {
+		return "", fmt.Errorf("not a tweet URL: %q", tweetURL)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "status" && parts[i+1] != "" {
+			return parts[i+1], nil
+		}
+	}
+	return "", fmt.Errorf("not a tweet URL: %q", tweetURL)
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if s := strings.TrimSpace(v); s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,538 @@
+// tx.go
+package bags
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// -------------------- Public Keys --------------------
+
+// PublicKey is a 32-byte Solana account address.
+type PublicKey [32]byte
+
+// ParsePublicKey decodes a base58 Solana address.
+func ParsePublicKey(s string) (PublicKey, error) {
+	var pk PublicKey
+	b, err := Base58Decode(strings.TrimSpace(s))
+	if err != nil {
+		return pk, fmt.Errorf("parse public key: %w", err)
+	}
+	if len(b) != len(pk) {
+		return pk, fmt.Errorf("parse public key: got %d bytes, want %d", len(b), len(pk))
+	}
+	copy(pk[:], b)
+	return pk, nil
+}
+
+// PublicKeyFromEd25519 returns the address of an ed25519 public key.
+func PublicKeyFromEd25519(k ed25519.PublicKey) PublicKey {
+	var pk PublicKey
+	copy(pk[:], k)
+	return pk
+}
+
+// String returns the base58 address.
+func (pk PublicKey) String() string { return Base58Encode(pk[:]) }
+
+// IsZero reports whether pk is all zeros.
+func (pk PublicKey) IsZero() bool { return pk == PublicKey{} }
+
+// MarshalText encodes the key as base58.
+func (pk PublicKey) MarshalText() ([]byte, error) { return []byte(pk.String()), nil }
+
+// UnmarshalText decodes a base58 key.
+func (pk *PublicKey) UnmarshalText(b []byte) error {
+	v, err := ParsePublicKey(string(b))
+	if err != nil {
+		return err
+	}
+	*pk = v
+	return nil
+}
+
+// -------------------- Signatures --------------------
+
+// ExplorerBaseURL prefixes transaction links returned by
+// Signature.ExplorerURL.
+var ExplorerBaseURL = "https://solscan.io/tx/"
+
+// Signature is a 64-byte Solana transaction signature. The first signature
+// of a transaction is its ID.
+type Signature [64]byte
+
+// ParseSignature decodes a base58 transaction signature.
+func ParseSignature(s string) (Signature, error) {
+	var sig Signature
+	b, err := Base58Decode(strings.TrimSpace(s))
+	if err != nil {
+		return sig, fmt.Errorf("parse signature: %w", err)
+	}
+	if len(b) != len(sig) {
+		return sig, fmt.Errorf("parse signature: got %d bytes, want %d", len(b), len(sig))
+	}
+	copy(sig[:], b)
+	return sig, nil
+}
+
+// String returns the base58 signature, or "" for the zero signature.
+func (s Signature) String() string {
+	if s.IsZero() {
+		return ""
+	}
+	return Base58Encode(s[:])
+}
+
+// IsZero reports whether s is unset.
+func (s Signature) IsZero() bool { return s == Signature{} }
+
+// ExplorerURL returns a block explorer link to the transaction, or "" for
+// the zero signature.
+func (s Signature) ExplorerURL() string {
+	if s.IsZero() {
+		return ""
+	}
+	return ExplorerBaseURL + s.String()
+}
+
+// MarshalText encodes the signature as base58; the zero signature encodes
+// as "".
+func (s Signature) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText decodes a base58 signature. An empty string, as sent for
+// launches that have not happened yet, yields the zero signature.
+func (s *Signature) UnmarshalText(b []byte) error {
+	if strings.TrimSpace(string(b)) == "" {
+		*s = Signature{}
+		return nil
+	}
+	v, err := ParseSignature(string(b))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
+
+// -------------------- Transactions --------------------
+
+// Transaction is a decoded Solana transaction as returned (base64) by the
+// launch and fee share endpoints. Both legacy and v0 messages are supported.
+//
+// Decoding and signing are purely local; no RPC access is needed.
+type Transaction struct {
+	Signatures []Signature
+	Message    Message
+}
+
+// Message is the signed portion of a transaction.
+type Message struct {
+	// Versioned is false for legacy messages. Version is only meaningful when
+	// Versioned is true (currently always 0).
+	Versioned bool
+	Version   uint8
+
+	Header              MessageHeader
+	AccountKeys         []PublicKey // static account keys
+	RecentBlockhash     [32]byte
+	Instructions        []CompiledInstruction
+	AddressTableLookups []AddressTableLookup // v0 only
+}
+
+// MessageHeader describes how AccountKeys are split into signer/readonly sets.
+type MessageHeader struct {
+	NumRequiredSignatures       uint8
+	NumReadonlySignedAccounts   uint8
+	NumReadonlyUnsignedAccounts uint8
+}
+
+// CompiledInstruction references accounts by index into the message's
+// account list (static keys followed by lookup-table keys).
+type CompiledInstruction struct {
+	ProgramIDIndex uint8
+	Accounts       []uint8
+	Data           []byte
+}
+
+// AddressTableLookup loads additional accounts from an address lookup table.
+type AddressTableLookup struct {
+	AccountKey      PublicKey
+	WritableIndexes []uint8
+	ReadonlyIndexes []uint8
+}
+
+// DecodeTransaction parses a base64-encoded transaction.
+func DecodeTransaction(b64 string) (*Transaction, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64))
+	if err != nil {
+		return nil, fmt.Errorf("decode transaction base64: %w", err)
+	}
+	return ParseTransaction(raw)
+}
+
+// ParseTransaction parses a serialized transaction.
+func ParseTransaction(raw []byte) (*Transaction, error) {
+	r := &txReader{b: raw}
+	n, err := r.count(64)
+	if err != nil {
+		return nil, fmt.Errorf("parse transaction: signatures: %w", err)
+	}
+	tx := &Transaction{Signatures: make([]Signature, n)}
+	for i := range tx.Signatures {
+		if err := r.read(tx.Signatures[i][:]); err != nil {
+			return nil, fmt.Errorf("parse transaction: signature %d: %w", i, err)
+		}
+	}
+	if err := tx.Message.parse(r); err != nil {
+		return nil, fmt.Errorf("parse transaction: %w", err)
+	}
+	if r.off != len(raw) {
+		return nil, fmt.Errorf("parse transaction: %d trailing bytes", len(raw)-r.off)
+	}
+	if int(tx.Message.Header.NumRequiredSignatures) != len(tx.Signatures) {
+		return nil, fmt.Errorf("parse transaction: %d signatures for %d required signers",
+			len(tx.Signatures), tx.Message.Header.NumRequiredSignatures)
+	}
+	return tx, nil
+}
+
+// FeePayer returns the account paying transaction fees (the first signer).
+func (tx *Transaction) FeePayer() (PublicKey, error) {
+	if len(tx.Message.AccountKeys) == 0 || tx.Message.Header.NumRequiredSignatures == 0 {
+		return PublicKey{}, errors.New("transaction has no fee payer")
+	}
+	return tx.Message.AccountKeys[0], nil
+}
+
+// Signature returns the transaction's ID, its first signature. It is zero
+// until the fee payer has signed.
+func (tx *Transaction) Signature() Signature {
+	if len(tx.Signatures) == 0 {
+		return Signature{}
+	}
+	return tx.Signatures[0]
+}
+
+// RequiredSigners returns the accounts whose signatures the transaction needs,
+// in signature order.
+func (tx *Transaction) RequiredSigners() []PublicKey {
+	n := int(tx.Message.Header.NumRequiredSignatures)
+	if n > len(tx.Message.AccountKeys) {
+		n = len(tx.Message.AccountKeys)
+	}
+	return append([]PublicKey(nil), tx.Message.AccountKeys[:n]...)
+}
+
+// MissingSigners returns required signers whose signature slot is still empty.
+func (tx *Transaction) MissingSigners() []PublicKey {
+	var missing []PublicKey
+	for i, pk := range tx.RequiredSigners() {
+		if i >= len(tx.Signatures) || tx.Signatures[i].IsZero() {
+			missing = append(missing, pk)
+		}
+	}
+	return missing
+}
+
+// ProgramID returns the program invoked by ix. The second result is false for
+// programs loaded from an address lookup table, which cannot be resolved
+// without RPC access.
+func (m *Message) ProgramID(ix CompiledInstruction) (PublicKey, bool) {
+	if int(ix.ProgramIDIndex) >= len(m.AccountKeys) {
+		return PublicKey{}, false
+	}
+	return m.AccountKeys[ix.ProgramIDIndex], true
+}
+
+// PartialSign signs the message with each key and stores the signature in
+// the slot of the matching required signer. Every key must belong to a
+// required signer; other signature slots are left untouched. It does not
+// run the client's PreSignHook; use BagsClient.SignTransaction for that.
+func (tx *Transaction) PartialSign(keys ...ed25519.PrivateKey) error {
+	msg, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	signers := tx.RequiredSigners()
+	if len(tx.Signatures) < len(signers) {
+		sigs := make([]Signature, len(signers))
+		copy(sigs, tx.Signatures)
+		tx.Signatures = sigs
+	}
+	for _, k := range keys {
+		if len(k) != ed25519.PrivateKeySize {
+			return fmt.Errorf("invalid ed25519 private key length %d", len(k))
+		}
+		pk := PublicKeyFromEd25519(k.Public().(ed25519.PublicKey))
+		idx := -1
+		for i, s := range signers {
+			if s == pk {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("%s is not a required signer", pk)
+		}
+		copy(tx.Signatures[idx][:], ed25519.Sign(k, msg))
+	}
+	return nil
+}
+
+// VerifySignatures checks every non-empty signature against the message.
+func (tx *Transaction) VerifySignatures() error {
+	msg, err := tx.Message.Serialize()
+	if err != nil {
+		return err
+	}
+	for i, pk := range tx.RequiredSigners() {
+		if i >= len(tx.Signatures) || tx.Signatures[i].IsZero() {
+			continue
+		}
+		if !ed25519.Verify(pk[:], msg, tx.Signatures[i][:]) {
+			return fmt.Errorf("invalid signature for %s", pk)
+		}
+	}
+	return nil
+}
+
+// Serialize encodes the transaction in wire format.
+func (tx *Transaction) Serialize() ([]byte, error) {
+	msg, err := tx.Message.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	putShortVec(&buf, len(tx.Signatures))
+	for _, s := range tx.Signatures {
+		buf.Write(s[:])
+	}
+	buf.Write(msg)
+	return buf.Bytes(), nil
+}
+
+// Base64 encodes the transaction for submission to an RPC node.
+func (tx *Transaction) Base64() (string, error) {
+	raw, err := tx.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// MarshalJSON encodes the transaction as a base64 string.
+func (tx *Transaction) MarshalJSON() ([]byte, error) {
+	s, err := tx.Base64()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// Serialize encodes the message; this is the payload that signers sign.
+func (m *Message) Serialize() ([]byte, error) {
+	if len(m.AccountKeys) > 255 {
+		return nil, fmt.Errorf("too many account keys: %d", len(m.AccountKeys))
+	}
+	var buf bytes.Buffer
+	if m.Versioned {
+		buf.WriteByte(0x80 | m.Version)
+	}
+	buf.WriteByte(m.Header.NumRequiredSignatures)
+	buf.WriteByte(m.Header.NumReadonlySignedAccounts)
+	buf.WriteByte(m.Header.NumReadonlyUnsignedAccounts)
+	putShortVec(&buf, len(m.AccountKeys))
+	for _, k := range m.AccountKeys {
+		buf.Write(k[:])
+	}
+	buf.Write(m.RecentBlockhash[:])
+	putShortVec(&buf, len(m.Instructions))
+	for _, ix := range m.Instructions {
+		buf.WriteByte(ix.ProgramIDIndex)
+		putShortVec(&buf, len(ix.Accounts))
+		buf.Write(ix.Accounts)
+		putShortVec(&buf, len(ix.Data))
+		buf.Write(ix.Data)
+	}
+	if m.Versioned {
+		putShortVec(&buf, len(m.AddressTableLookups))
+		for _, l := range m.AddressTableLookups {
+			buf.Write(l.AccountKey[:])
+			putShortVec(&buf, len(l.WritableIndexes))
+			buf.Write(l.WritableIndexes)
+			putShortVec(&buf, len(l.ReadonlyIndexes))
+			buf.Write(l.ReadonlyIndexes)
+		}
+	} else if len(m.AddressTableLookups) > 0 {
+		return nil, errors.New("legacy message cannot have address table lookups")
+	}
+	return buf.Bytes(), nil
+}
+
+// ------- Internal Helpers -------
+
+func (m *Message) parse(r *txReader) error {
+	first, err := r.byte()
+	if err != nil {
+		return fmt.Errorf("message header: %w", err)
+	}
+	if first&0x80 != 0 {
+		m.Versioned = true
+		m.Version = first & 0x7f
+		if m.Version != 0 {
+			return fmt.Errorf("unsupported message version %d", m.Version)
+		}
+		if first, err = r.byte(); err != nil {
+			return fmt.Errorf("message header: %w", err)
+		}
+	}
+	m.Header.NumRequiredSignatures = first
+	if m.Header.NumReadonlySignedAccounts, err = r.byte(); err != nil {
+		return fmt.Errorf("message header: %w", err)
+	}
+	if m.Header.NumReadonlyUnsignedAccounts, err = r.byte(); err != nil {
+		return fmt.Errorf("message header: %w", err)
+	}
+
+	n, err := r.count(32)
+	if err != nil {
+		return fmt.Errorf("account keys: %w", err)
+	}
+	m.AccountKeys = make([]PublicKey, n)
+	for i := range m.AccountKeys {
+		if err := r.read(m.AccountKeys[i][:]); err != nil {
+			return fmt.Errorf("account key %d: %w", i, err)
+		}
+	}
+	if err := r.read(m.RecentBlockhash[:]); err != nil {
+		return fmt.Errorf("recent blockhash: %w", err)
+	}
+
+	// Program index plus two empty vectors.
+	if n, err = r.count(3); err != nil {
+		return fmt.Errorf("instructions: %w", err)
+	}
+	m.Instructions = make([]CompiledInstruction, n)
+	for i := range m.Instructions {
+		ix := &m.Instructions[i]
+		if ix.ProgramIDIndex, err = r.byte(); err != nil {
+			return fmt.Errorf("instruction %d: %w", i, err)
+		}
+		if ix.Accounts, err = r.bytesVec(); err != nil {
+			return fmt.Errorf("instruction %d accounts: %w", i, err)
+		}
+		if ix.Data, err = r.bytesVec(); err != nil {
+			return fmt.Errorf("instruction %d data: %w", i, err)
+		}
+	}
+
+	if !m.Versioned {
+		return nil
+	}
+	// Account key plus two empty vectors.
+	if n, err = r.count(34); err != nil {
+		return fmt.Errorf("address table lookups: %w", err)
+	}
+	m.AddressTableLookups = make([]AddressTableLookup, n)
+	for i := range m.AddressTableLookups {
+		l := &m.AddressTableLookups[i]
+		if err := r.read(l.AccountKey[:]); err != nil {
+			return fmt.Errorf("address table lookup %d: %w", i, err)
+		}
+		if l.WritableIndexes, err = r.bytesVec(); err != nil {
+			return fmt.Errorf("address table lookup %d: %w", i, err)
+		}
+		if l.ReadonlyIndexes, err = r.bytesVec(); err != nil {
+			return fmt.Errorf("address table lookup %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+type txReader struct {
+	b   []byte
+	off int
+}
+
+var errShortTx = errors.New("unexpected end of transaction")
+
+func (r *txReader) byte() (byte, error) {
+	if r.off >= len(r.b) {
+		return 0, errShortTx
+	}
+	v := r.b[r.off]
+	r.off++
+	return v, nil
+}
+
+func (r *txReader) read(dst []byte) error {
+	if len(r.b)-r.off < len(dst) {
+		return errShortTx
+	}
+	copy(dst, r.b[r.off:])
+	r.off += len(dst)
+	return nil
+}
+
+// shortVec reads a compact-u16 length prefix.
+func (r *txReader) shortVec() (int, error) {
+	var v int
+	for i := 0; i < 3; i++ {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		v |= int(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			if v > 0xffff {
+				break
+			}
+			return v, nil
+		}
+	}
+	return 0, errors.New("invalid compact-u16 length")
+}
+
+// count reads a compact-u16 element count and checks that the remaining
+// bytes can hold that many elements of at least minSize bytes, so a crafted
+// length cannot force a large allocation.
+func (r *txReader) count(minSize int) (int, error) {
+	n, err := r.shortVec()
+	if err != nil {
+		return 0, err
+	}
+	if n > (len(r.b)-r.off)/minSize {
+		return 0, errShortTx
+	}
+	return n, nil
+}
+
+func (r *txReader) bytesVec() ([]byte, error) {
+	n, err := r.count(1)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, n)
+	if err := r.read(out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// putShortVec writes a compact-u16 length prefix.
+func putShortVec(buf *bytes.Buffer, n int) {
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n == 0 {
+			buf.WriteByte(b)
+			return
+		}
+		buf.WriteByte(b | 0x80)
+	}
+}
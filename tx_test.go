@@ -0,0 +1,148 @@
+// tx_test.go
+package bags_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+
+	bags "github.com/dzhisl/bagsfm-go"
+)
+
+// testKey derives a deterministic keypair from seed.
+func testKey(seed byte) (ed25519.PrivateKey, bags.PublicKey) {
+	k := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize))
+	return k, bags.PublicKeyFromEd25519(k.Public().(ed25519.PublicKey))
+}
+
+// testTx builds a transaction paid by payer with one instruction invoking
+// the last static account.
+func testTx(payer bags.PublicKey, versioned bool) *bags.Transaction {
+	tx := &bags.Transaction{Message: bags.Message{
+		Versioned:       versioned,
+		Header:          bags.MessageHeader{NumRequiredSignatures: 1, NumReadonlyUnsignedAccounts: 1},
+		AccountKeys:     []bags.PublicKey{payer, {7}, bags.SystemProgramID},
+		RecentBlockhash: [32]byte{1, 2, 3},
+		Instructions:    []bags.CompiledInstruction{{ProgramIDIndex: 2, Accounts: []uint8{0, 1}, Data: []byte{2, 0, 0, 0}}},
+	}}
+	if versioned {
+		tx.Message.AddressTableLookups = []bags.AddressTableLookup{{AccountKey: bags.PublicKey{9}, WritableIndexes: []uint8{0}}}
+	}
+	tx.Signatures = make([]bags.Signature, 1)
+	return tx
+}
+
+func TestTransactionRoundTrip(t *testing.T) {
+	key, payer := testKey(1)
+	for _, versioned := range []bool{false, true} {
+		tx := testTx(payer, versioned)
+		if err := tx.PartialSign(key); err != nil {
+			t.Fatal(err)
+		}
+		raw, err := tx.Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := bags.ParseTransaction(raw)
+		if err != nil {
+			t.Fatalf("versioned=%v: %v", versioned, err)
+		}
+		if err := got.VerifySignatures(); err != nil {
+			t.Errorf("versioned=%v: %v", versioned, err)
+		}
+		if len(got.MissingSigners()) != 0 || got.Signature() != tx.Signature() {
+			t.Errorf("versioned=%v: signatures did not survive the round trip", versioned)
+		}
+		again, _ := got.Serialize()
+		if !bytes.Equal(again, raw) {
+			t.Errorf("versioned=%v: re-serialized bytes differ", versioned)
+		}
+	}
+}
+
+func TestParseTransactionMalformed(t *testing.T) {
+	_, payer := testKey(1)
+	legacy, _ := testTx(payer, false).Serialize()
+	v0, _ := testTx(payer, true).Serialize()
+
+	twoSigs := testTx(payer, false)
+	twoSigs.Signatures = make([]bags.Signature, 2)
+	mismatch, _ := twoSigs.Serialize()
+
+	// A legacy message whose first byte claims version 1.
+	badVersion := append([]byte{0}, 0x81)
+	badVersion = append(badVersion, legacy[65:]...)
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{"empty", nil},
+		{"compact-u16 without terminator", []byte{0xff, 0xff, 0xff, 0x7f}},
+		{"compact-u16 above 0xffff", []byte{0xff, 0xff, 0x7f}},
+		{"signature count beyond buffer", []byte{0xff, 0xff, 0x03, 0}},
+		{"truncated signature", legacy[:40]},
+		{"truncated header", legacy[:66]},
+		{"truncated legacy message", legacy[:len(legacy)-1]},
+		{"truncated lookup table", v0[:len(v0)-1]},
+		{"trailing bytes", append(append([]byte(nil), legacy...), 0)},
+		{"signature count above header", mismatch},
+		{"unsupported version", badVersion},
+		{"account count beyond buffer", append(append([]byte(nil), legacy[:68]...), 0xff, 0x01)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tx, err := bags.ParseTransaction(tt.raw); err == nil {
+				t.Errorf("ParseTransaction succeeded: %+v", tx)
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionBase64(t *testing.T) {
+	_, payer := testKey(1)
+	b64, err := testTx(payer, true).Base64()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bags.DecodeTransaction(b64); err != nil {
+		t.Errorf("DecodeTransaction(valid) = %v", err)
+	}
+	for _, in := range []string{"", "!!!", b64[:len(b64)-4]} {
+		if _, err := bags.DecodeTransaction(in); err == nil {
+			t.Errorf("DecodeTransaction(%.20q) succeeded", in)
+		}
+	}
+}
+
+func TestPartialSign(t *testing.T) {
+	key, payer := testKey(1)
+	other, _ := testKey(2)
+	tests := []struct {
+		name string
+		keys []ed25519.PrivateKey
+		ok   bool
+	}{
+		{"required signer", []ed25519.PrivateKey{key}, true},
+		{"not a signer", []ed25519.PrivateKey{other}, false},
+		{"short key", []ed25519.PrivateKey{key[:10]}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := testTx(payer, false)
+			err := tx.PartialSign(tt.keys...)
+			if (err == nil) != tt.ok {
+				t.Fatalf("PartialSign() = %v, want ok = %v", err, tt.ok)
+			}
+			if tt.ok && tx.VerifySignatures() != nil {
+				t.Error("signature does not verify")
+			}
+		})
+	}
+
+	legacy := testTx(payer, false)
+	legacy.Message.AddressTableLookups = []bags.AddressTableLookup{{}}
+	if err := legacy.PartialSign(key); err == nil {
+		t.Error("signed a legacy message with address table lookups")
+	}
+}
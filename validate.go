@@ -0,0 +1,78 @@
+// validate.go
+package bags
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrRequestRejected is matched by errors from registered validators.
+var ErrRequestRejected = errors.New("request rejected by validator")
+
+// -------------------- Request Validators --------------------
+
+// ValidationError is returned when a validator registered with
+// RegisterValidator rejects a request.
+type ValidationError struct {
+	Request string // request type, e.g. "CreateTokenLaunchTxRequest"
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Request, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error { return e.Err }
+
+func (e *ValidationError) Is(target error) bool { return target == ErrRequestRejected }
+
+// RegisterValidator adds fn to the checks c runs on every *T before sending
+// it, e.g. to cap CreateTokenLaunchTxRequest.InitialBuyLamports or restrict
+// the socials of CreateTokenInfoRequest. Validators run in registration
+// order before any network call, including for requests built internally by
+// helpers such as LaunchFromTweet, and see Signer defaults already applied.
+// They must not modify the request.
+//
+// Validated request types are CreateTokenInfoRequest,
+// CreateTokenLaunchConfigRequest, CreateTokenLaunchTxRequest,
+// CreateFeeShareConfigRequest, FeeShareRecipientsRequest, and
+// ClaimTransactionsRequest.
+func RegisterValidator[T any](c *BagsClient, fn func(*T) error) {
+	t := reflect.TypeFor[T]()
+	c.validators.mu.Lock()
+	defer c.validators.mu.Unlock()
+	if c.validators.fns == nil {
+		c.validators.fns = make(map[reflect.Type][]func(any) error)
+	}
+	c.validators.fns[t] = append(c.validators.fns[t], func(v any) error { return fn(v.(*T)) })
+}
+
+// ValidateRequest runs c's validators for T on in without sending it, so a
+// request can be checked as soon as it is built.
+func ValidateRequest[T any](c *BagsClient, in *T) error {
+	return c.validators.run(in)
+}
+
+// ------- Internal Helpers -------
+
+type validatorRegistry struct {
+	mu  sync.RWMutex
+	fns map[reflect.Type][]func(any) error
+}
+
+// run applies the validators registered for in's type, stopping at the
+// first rejection.
+func (r *validatorRegistry) run(in any) error {
+	t := reflect.TypeOf(in).Elem()
+	r.mu.RLock()
+	fns := r.fns[t]
+	r.mu.RUnlock()
+	for _, fn := range fns {
+		if err := fn(in); err != nil {
+			return &ValidationError{Request: t.Name(), Err: err}
+		}
+	}
+	return nil
+}